@@ -0,0 +1,23 @@
+package middlewares
+
+import (
+	"net/http"
+
+	"github.com/lunny/tango"
+	"github.com/missdeer/wego/modules/ipblock"
+	"github.com/missdeer/wego/modules/utils"
+)
+
+// IpBlock rejects requests which action is denied for the client ip
+// by the admin managed block list.
+func IpBlock() tango.HandlerFunc {
+	return func(ctx *tango.Context) {
+		ip := utils.IP(ctx.Req())
+		if id := ipblock.Match(ip, ipblock.RequestAction(ctx.Req())); id > 0 {
+			ctx.Debug("ip blocked:", ip, "entry", id)
+			ctx.Abort(http.StatusForbidden)
+			return
+		}
+		ctx.Next()
+	}
+}
@@ -13,6 +13,8 @@ type Category struct {
 	Name  string `xorm:"varchar(30) unique"`
 	Slug  string `xorm:"varchar(100) unique"`
 	Order int    `xorm:"index"`
+	// posts of Q&A category are questions which can have an accepted answer
+	IsQuestion bool
 }
 
 func (m *Category) String() string {
@@ -1,6 +1,7 @@
 package models
 
 import (
+	"fmt"
 	"time"
 
 	"github.com/missdeer/wego/modules/utils"
@@ -15,8 +16,15 @@ type Comment struct {
 	Message      string `xorm:"text"`
 	MessageCache string `xorm:"text"`
 	Floor        int
-	Status       int       `xorm:"index"`
-	Created      time.Time `xorm:"created"`
+	// the comment which this one replies to
+	ParentId  int64 `xorm:"index"`
+	Status    int   `xorm:"index"`
+	IsHide    bool  `xorm:"index"`
+	IsDelete  bool  `xorm:"index"`
+	EditTimes int
+	Score     int `xorm:"index"`
+	Edited    time.Time
+	Created   time.Time `xorm:"created"`
 }
 
 func (m *Comment) GetMessageCache() string {
@@ -27,6 +35,17 @@ func (m *Comment) GetMessageCache() string {
 	}
 }
 
+// author can edit the comment within the grace window after posting
+func (m *Comment) InEditWindow() bool {
+	if m.IsDelete {
+		return false
+	}
+	if setting.CommentEditMinutes <= 0 {
+		return true
+	}
+	return time.Since(m.Created) < time.Duration(setting.CommentEditMinutes)*time.Minute
+}
+
 func (m *Comment) String() string {
 	return utils.ToStr(m.Id)
 }
@@ -40,6 +59,11 @@ func (c *Comment) User() *User {
 	return &user
 }
 
+// link to the comment on the page of post which lists it
+func (c *Comment) Link() string {
+	return floorLink(fmt.Sprintf("%spost/%d", setting.AppUrl, c.PostId), c.Floor)
+}
+
 func (c *Comment) Post() *Post {
 	var post Post
 	has, err := orm.Id(c.PostId).Get(&post)
@@ -49,19 +73,81 @@ func (c *Comment) Post() *Post {
 	return &post
 }
 
+// InsertComment allocates the next floor of the post and inserts the comment.
+// The floor counter of post is increased by a single statement in the same
+// transaction, so concurrent comments never share a floor.
 func InsertComment(comment *Comment) error {
-	_, err := orm.Insert(comment)
-	return err
+	sess := orm.NewSession()
+	defer sess.Close()
+	if err := sess.Begin(); err != nil {
+		return err
+	}
+
+	// posts commented before the counter existed continue from their comments
+	sql := fmt.Sprintf("UPDATE %s SET floors = (CASE WHEN floors > 0 THEN floors "+
+		"ELSE (SELECT COUNT(*) FROM %s WHERE post_id = ?) END) + 1 WHERE id = ?", orm.Quote("post"), orm.Quote("comment"))
+	if _, err := sess.Exec(sql, comment.PostId, comment.PostId); err != nil {
+		sess.Rollback()
+		return err
+	}
+
+	var post Post
+	if has, err := sess.Id(comment.PostId).Cols("floors").Get(&post); err != nil {
+		sess.Rollback()
+		return err
+	} else if !has {
+		sess.Rollback()
+		return ErrNotExist
+	}
+
+	comment.Floor = post.Floors
+	if _, err := sess.Insert(comment); err != nil {
+		sess.Rollback()
+		return err
+	}
+	return sess.Commit()
+}
+
+func GetCommentById(id int64) (*Comment, error) {
+	var comment Comment
+	has, err := orm.Id(id).Get(&comment)
+	if err != nil {
+		return nil, err
+	}
+	if !has {
+		return nil, ErrNotExist
+	}
+	return &comment, nil
 }
 
 func RecentCommentsByUserId(userId int64, limit int) ([]Comment, error) {
 	var comments = make([]Comment, 0)
-	err := orm.Where("user_id = ?", userId).Limit(limit).Find(&comments)
+	err := orm.Where("user_id = ? AND is_delete = ?", userId, false).Limit(limit).Find(&comments)
 	return comments, err
 }
 
 func GetCommentsByPostId(comments *[]*Comment, postId int64) error {
-	return orm.Find(comments, &Comment{PostId: postId})
+	return orm.Asc("floor").Find(comments, &Comment{PostId: postId})
+}
+
+func FindCommentsByIds(ids []int64) ([]*Comment, error) {
+	var comments = make([]*Comment, 0)
+	err := orm.In("id", ids).Find(&comments)
+	return comments, err
+}
+
+// a page of comments of post in the order of floors
+func FindCommentsByPostId(postId int64, limit, start int) ([]*Comment, error) {
+	var comments = make([]*Comment, 0)
+	err := orm.Where("post_id = ?", postId).Asc("floor").Limit(limit, start).Find(&comments)
+	return comments, err
+}
+
+// a page of comments of post with the highest score first
+func FindCommentsByPostIdOrderByScore(postId int64, limit, start int) ([]*Comment, error) {
+	var comments = make([]*Comment, 0)
+	err := orm.Where("post_id = ?", postId).Desc("score").Asc("floor").Limit(limit, start).Find(&comments)
+	return comments, err
 }
 
 func CountCommentsByPostId(postId int64) (int64, error) {
@@ -72,6 +158,18 @@ func CountCommentsByUserId(userId int64) (int64, error) {
 	return orm.Count(&Comment{UserId: userId})
 }
 
-func CountCommentsLTEId(id int64) (int64, error) {
-	return orm.Where("id <= ?", id).Count(new(Comment))
+// DeleteComment clears the message of comment but keeps it as a placeholder,
+// so the floors of the thread stay stable.
+func DeleteComment(comment *Comment) error {
+	comment.IsDelete = true
+	comment.Message = ""
+	comment.MessageCache = ""
+	if err := UpdateById(comment.Id, comment, "is_delete", "message", "message_cache"); err != nil {
+		return err
+	}
+	// a deleted comment can't stay the accepted answer
+	if _, err := orm.Where("answer_id = ?", comment.Id).Cols("answer_id").NoAutoTime().Update(&Post{}); err != nil {
+		return err
+	}
+	return DeleteCommentNotifications(comment)
 }
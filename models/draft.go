@@ -0,0 +1,146 @@
+package models
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/missdeer/wego/modules/utils"
+	"github.com/missdeer/wego/setting"
+)
+
+// autosaved draft of new post, post edit or comment,
+// one draft for each type and post of user
+type Draft struct {
+	Id      int64
+	UserId  int64 `xorm:"index"`
+	Type    int   `xorm:"index"`
+	PostId  int64 `xorm:"index"`
+	TopicId int64
+	Title   string `xorm:"varchar(60)"`
+	Content string `xorm:"text"`
+	// updated time of the post when the edit started
+	Base    time.Time
+	Created time.Time `xorm:"created"`
+	Updated time.Time `xorm:"updated"`
+}
+
+func (m *Draft) String() string {
+	return utils.ToStr(m.Id)
+}
+
+func (m *Draft) IsPost() bool {
+	return m.Type == setting.DRAFT_TYPE_POST
+}
+
+func (m *Draft) IsEdit() bool {
+	return m.Type == setting.DRAFT_TYPE_EDIT
+}
+
+func (m *Draft) IsComment() bool {
+	return m.Type == setting.DRAFT_TYPE_COMMENT
+}
+
+func (m *Draft) Post() *Post {
+	if m.PostId == 0 {
+		return nil
+	}
+	post, err := GetPostById(m.PostId)
+	if err != nil {
+		return nil
+	}
+	return post
+}
+
+func (m *Draft) Topic() *Topic {
+	if m.TopicId == 0 {
+		return nil
+	}
+	topic, err := GetTopicById(m.TopicId)
+	if err != nil {
+		return nil
+	}
+	return topic
+}
+
+// page to continue the draft
+func (m *Draft) Link() string {
+	switch m.Type {
+	case setting.DRAFT_TYPE_EDIT:
+		return fmt.Sprintf("%spost/%d/edit", setting.AppUrl, m.PostId)
+	case setting.DRAFT_TYPE_COMMENT:
+		return fmt.Sprintf("%spost/%d#post-reply", setting.AppUrl, m.PostId)
+	}
+	if topic := m.Topic(); topic != nil {
+		return fmt.Sprintf("%snew?topic=%s", setting.AppUrl, topic.Slug)
+	}
+	return setting.AppUrl
+}
+
+// draft of edit is conflicted when the post has been changed after the edit started
+func (m *Draft) IsConflict() bool {
+	if m.Type != setting.DRAFT_TYPE_EDIT {
+		return false
+	}
+	return m.ConflictWith(m.Post())
+}
+
+// ConflictWith checks if the post has been edited after the base time of draft.
+func (m *Draft) ConflictWith(post *Post) bool {
+	return post != nil && post.Updated.Unix() > m.Base.Unix()
+}
+
+func GetDraft(userId int64, typ int, postId int64) (*Draft, error) {
+	var draft Draft
+	has, err := orm.Where("user_id = ? AND type = ? AND post_id = ?", userId, typ, postId).Get(&draft)
+	if err != nil {
+		return nil, err
+	}
+	if !has {
+		return nil, ErrNotExist
+	}
+	return &draft, nil
+}
+
+func GetDraftById(userId, id int64) (*Draft, error) {
+	var draft Draft
+	has, err := orm.Where("id = ? AND user_id = ?", id, userId).Get(&draft)
+	if err != nil {
+		return nil, err
+	}
+	if !has {
+		return nil, ErrNotExist
+	}
+	return &draft, nil
+}
+
+// SaveDraft inserts the draft or updates the existing one of the same type and post,
+// the base of an existing draft is kept.
+func SaveDraft(draft *Draft) error {
+	old, err := GetDraft(draft.UserId, draft.Type, draft.PostId)
+	if err == ErrNotExist {
+		_, err = orm.Insert(draft)
+		return err
+	} else if err != nil {
+		return err
+	}
+
+	draft.Id = old.Id
+	draft.Base = old.Base
+	_, err = orm.Id(draft.Id).Cols("topic_id", "title", "content", "updated").Update(draft)
+	return err
+}
+
+func DeleteDraft(userId int64, typ int, postId int64) error {
+	_, err := orm.Where("user_id = ? AND type = ? AND post_id = ?", userId, typ, postId).Delete(new(Draft))
+	return err
+}
+
+func FindDraftsByUserId(userId int64, limit, start int) ([]*Draft, error) {
+	var drafts = make([]*Draft, 0)
+	err := orm.Where("user_id = ?", userId).Desc("updated").Limit(limit, start).Find(&drafts)
+	return drafts, err
+}
+
+func CountDraftsByUserId(userId int64) (int64, error) {
+	return orm.Count(&Draft{UserId: userId})
+}
@@ -0,0 +1,18 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/missdeer/wego/modules/utils"
+)
+
+func TestDraftConflictWith(t *testing.T) {
+	base := time.Now()
+	draft := &Draft{Base: base}
+
+	// only the posts edited after the draft base are conflicts
+	ThrowFail(t, AssertIs(draft.ConflictWith(&Post{Updated: base}), false))
+	ThrowFail(t, AssertIs(draft.ConflictWith(&Post{Updated: base.Add(time.Second)}), true))
+	ThrowFail(t, AssertIs(draft.ConflictWith(nil), false))
+}
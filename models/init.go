@@ -35,10 +35,17 @@ func Init(isProMode bool) {
 
 	err = orm.Sync2(new(Setting), new(Category), new(Post), new(Image),
 		new(User), new(FavoritePost), new(Follow), new(Topic), new(FollowTopic),
-		new(Page), new(Notification), new(Comment), new(Bulletin))
+		new(Page), new(Notification), new(Comment), new(Bulletin), new(IpBlock),
+		new(Moderator), new(PostRevision), new(Draft), new(Tag), new(PostTag), new(Vote),
+		new(Poll), new(PollOption), new(PollVote),
+		new(PostRead), new(CategoryRead), new(PageRevision), new(Menu))
 	if err != nil {
 		panic(err)
 	}
 
+	if err = initMenus(); err != nil {
+		panic(err)
+	}
+
 	social.SetORM(orm)
 }
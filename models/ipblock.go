@@ -0,0 +1,34 @@
+package models
+
+import "time"
+
+// blocked ip address or cidr range
+// BlockRead/BlockRegister/BlockWrite: actions denied for the range
+// Expired: zero time means the entry never expires
+type IpBlock struct {
+	Id            int64
+	Cidr          string `xorm:"varchar(50) index"`
+	BlockRead     bool   `xorm:"index"`
+	BlockRegister bool   `xorm:"index"`
+	BlockWrite    bool   `xorm:"index"`
+	Note          string `xorm:"varchar(255)"`
+	Hits          int64
+	Expired       time.Time `xorm:"index"`
+	Created       time.Time `xorm:"created"`
+	Updated       time.Time `xorm:"updated"`
+}
+
+func (m *IpBlock) IsExpired() bool {
+	return !m.Expired.IsZero() && m.Expired.Before(time.Now())
+}
+
+func FindIpBlocks() ([]IpBlock, error) {
+	var blocks = make([]IpBlock, 0)
+	err := orm.Find(&blocks)
+	return blocks, err
+}
+
+func AddIpBlockHits(id int64, hits int64) error {
+	_, err := orm.Id(id).Incr("hits", hits).Update(new(IpBlock))
+	return err
+}
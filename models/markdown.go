@@ -0,0 +1,121 @@
+package models
+
+import (
+	"fmt"
+	"html"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/missdeer/wego/modules/utils"
+)
+
+// [[title]] links to the wiki page of title
+var wikiLinkRegexp = regexp.MustCompile(`\[\[([^\[\]\n]{1,60})\]\]`)
+
+// the forum rules of markdown, links to users, posts, floors and wiki pages.
+// The links are made without looking up the database, so the caches don't
+// keep titles, the hidden state of posts or missing pages, they are shown by
+// the page.
+func init() {
+	utils.RegisterMarkdownRule(&utils.MarkdownRule{
+		Name:    "floor",
+		Pattern: regexp.MustCompile(`post/(\d+)#reply(\d+)`),
+		Render: func(m []string) (string, bool) {
+			post, ok := markdownPost(m[1])
+			if !ok {
+				return "", false
+			}
+			floor, _ := strconv.Atoi(m[2])
+			return markdownPostLink(post.FloorLink(floor), m[0]), true
+		},
+	})
+	utils.RegisterMarkdownRule(&utils.MarkdownRule{
+		Name:    "post",
+		Pattern: regexp.MustCompile(`#(\d+)\b`),
+		Render: func(m []string) (string, bool) {
+			post, ok := markdownPost(m[1])
+			if !ok {
+				return "", false
+			}
+			return markdownPostLink(post.Link(), m[0]), true
+		},
+	})
+	utils.RegisterMarkdownRule(&utils.MarkdownRule{
+		Name:    "mention",
+		Pattern: regexp.MustCompile(`@([\w\-]+)`),
+		Render: func(m []string) (string, bool) {
+			user := User{UserName: m[1]}
+			return fmt.Sprintf(`<a href="%s">%s</a>`, html.EscapeString(user.Link()), html.EscapeString(m[0])), true
+		},
+	})
+	utils.RegisterMarkdownRule(&utils.MarkdownRule{
+		Name:    "wiki",
+		Pattern: wikiLinkRegexp,
+		Render: func(m []string) (string, bool) {
+			title := strings.TrimSpace(m[1])
+			uri := WikiPageUri(title)
+			if len(uri) == 0 {
+				return "", false
+			}
+			page := Page{Uri: uri}
+			return fmt.Sprintf(`<a class="wiki-link" href="%s">%s</a>`, html.EscapeString(page.Link()), html.EscapeString(title)), true
+		},
+	})
+}
+
+// post linked from markdown by id
+func markdownPost(id string) (*Post, bool) {
+	postId, err := strconv.ParseInt(id, 10, 64)
+	if err != nil || postId <= 0 {
+		return nil, false
+	}
+	return &Post{Id: postId}, true
+}
+
+// links to posts are marked to show their titles on hover
+func markdownPostLink(link, text string) string {
+	return fmt.Sprintf(`<a class="post-link" href="%s">%s</a>`, html.EscapeString(link), html.EscapeString(text))
+}
+
+// RerenderMarkdown renders the markdown of every post, comment, page and
+// notification again and saves the caches, used after the renderer changed.
+func RerenderMarkdown() error {
+	for _, t := range []struct{ table, content, cache string }{
+		{"post", "content", "content_cache"},
+		{"comment", "message", "message_cache"},
+		{"page", "content", "content_cache"},
+		{"notification", "content", "content_cache"},
+	} {
+		if err := rerenderTable(t.table, t.content, t.cache); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// rerenderTable renders the markdown of the content column of every row of
+// table again and saves it to the cache column, 100 rows at once.
+func rerenderTable(table, contentCol, cacheCol string) error {
+	var lastId int64
+	for {
+		rows, err := orm.Query("SELECT id, "+contentCol+" FROM "+table+" WHERE id > ? ORDER BY id LIMIT 100", lastId)
+		if err != nil {
+			return err
+		}
+		if len(rows) == 0 {
+			return nil
+		}
+		for _, row := range rows {
+			id, err := strconv.ParseInt(string(row["id"]), 10, 64)
+			if err != nil {
+				return err
+			}
+			cache := utils.RenderMarkdown(string(row[contentCol]))
+			if _, err := orm.Exec("UPDATE "+table+" SET "+cacheCol+" = ? WHERE id = ?", cache, id); err != nil {
+				return err
+			}
+			lastId = id
+		}
+	}
+}
@@ -0,0 +1,29 @@
+package models
+
+import (
+	"strings"
+	"testing"
+
+	. "github.com/missdeer/wego/modules/utils"
+	"github.com/missdeer/wego/setting"
+)
+
+func TestMarkdownForumLinks(t *testing.T) {
+	setting.AppUrl = "/"
+
+	// links are made without the database, titles are left to the page
+	body := RenderMarkdown("see #12, post/12#reply3, @bob-1 and [[Go Tips]]")
+	for _, link := range []string{
+		`<a class="post-link" href="/post/12" rel="nofollow ugc">#12</a>`,
+		`<a class="post-link" href="/post/12#reply3" rel="nofollow ugc">post/12#reply3</a>`,
+		`<a href="/user/bob-1" rel="nofollow ugc">@bob-1</a>`,
+		`<a class="wiki-link" href="/page/go-tips" rel="nofollow ugc">Go Tips</a>`,
+	} {
+		ThrowFail(t, AssertIs(strings.Contains(body, link), true), link, body)
+	}
+	ThrowFail(t, AssertIs(strings.Contains(body, "title="), false), body)
+
+	// no links inside words, emails and code
+	body = RenderMarkdown("a#12 mail@bob.com `#12 @bob`")
+	ThrowFail(t, AssertIs(strings.Contains(body, "<a "), false), body)
+}
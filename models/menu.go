@@ -0,0 +1,151 @@
+package models
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Unknwon/i18n"
+	"github.com/missdeer/wego/setting"
+)
+
+// link of navbar or footer, the menus of navbar with ParentId are the
+// items of the dropdown of parent menu.
+type Menu struct {
+	Id       int64
+	ParentId int64  `xorm:"index"`
+	Position int    `xorm:"index"`
+	Name     string `xorm:"varchar(60)"`
+	Url      string `xorm:"varchar(255)"`
+	Order    int    `xorm:"index"`
+	IsBlank  bool
+	Created  time.Time `xorm:"created"`
+	Updated  time.Time `xorm:"updated"`
+}
+
+// Link is the url of menu, the urls which start with / are under AppUrl.
+func (m *Menu) Link() string {
+	if strings.HasPrefix(m.Url, "/") && !strings.HasPrefix(m.Url, "//") {
+		return setting.AppUrl + m.Url[1:]
+	}
+	return m.Url
+}
+
+// Title is the name of menu in lang, the names without dots are looked up
+// in locale and the names which aren't found are shown as they are.
+func (m *Menu) Title(lang string) string {
+	if strings.Contains(m.Name, ".") {
+		return m.Name
+	}
+	return i18n.Tr(lang, m.Name)
+}
+
+// menu with its dropdown items
+type MenuItem struct {
+	*Menu
+	Children []*Menu
+}
+
+var (
+	menuCache   map[int][]*MenuItem
+	menuCacheMu sync.RWMutex
+	// increased when the cache is cleared, the menus loaded before aren't cached
+	menuCacheVersion int64
+)
+
+// GetMenus returns the menus of position, the menus are cached until they
+// are changed.
+func GetMenus(position int) []*MenuItem {
+	menuCacheMu.RLock()
+	cache := menuCache
+	version := menuCacheVersion
+	menuCacheMu.RUnlock()
+	if cache != nil {
+		return cache[position]
+	}
+
+	var menus = make([]Menu, 0)
+	if err := orm.Asc("order", "id").Find(&menus); err != nil {
+		return nil
+	}
+	cache = make(map[int][]*MenuItem)
+	items := make(map[int64]*MenuItem)
+	for i := range menus {
+		menu := &menus[i]
+		if menu.ParentId == 0 {
+			item := &MenuItem{Menu: menu}
+			items[menu.Id] = item
+			cache[menu.Position] = append(cache[menu.Position], item)
+		}
+	}
+	for i := range menus {
+		menu := &menus[i]
+		if item, ok := items[menu.ParentId]; ok {
+			item.Children = append(item.Children, menu)
+		}
+	}
+
+	menuCacheMu.Lock()
+	if version == menuCacheVersion {
+		menuCache = cache
+	}
+	menuCacheMu.Unlock()
+	return cache[position]
+}
+
+// ClearMenuCache is called after the menus are changed.
+func ClearMenuCache() {
+	menuCacheMu.Lock()
+	menuCache = nil
+	menuCacheVersion++
+	menuCacheMu.Unlock()
+}
+
+// the menus which were in templates before they were stored
+func initMenus() error {
+	cnt, err := orm.Count(new(Menu))
+	if err != nil || cnt > 0 {
+		return err
+	}
+
+	navbar := []Menu{
+		{Name: "home", Url: "/"},
+		{Name: "activity", Url: "/topic/activity"},
+		{Name: "tool", Url: "#"},
+	}
+	for i := range navbar {
+		navbar[i].Position = setting.MENU_NAVBAR
+		navbar[i].Order = i
+		if _, err := orm.Insert(&navbar[i]); err != nil {
+			return err
+		}
+	}
+	tool := Menu{ParentId: navbar[2].Id, Name: "gopm", Url: "http://gopm.io", IsBlank: true}
+	if _, err := orm.Insert(&tool); err != nil {
+		return err
+	}
+
+	footer := []Menu{
+		{Name: "about", Url: "/about"},
+		{Name: "FAQ", Url: "/faq"},
+	}
+	for i := range footer {
+		footer[i].Position = setting.MENU_FOOTER
+		footer[i].Order = i
+		if _, err := orm.Insert(&footer[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// menus which have no parent, the dropdown items are under them
+func FindTopMenus(position int) ([]Menu, error) {
+	var menus = make([]Menu, 0)
+	err := orm.Where("parent_id = ? AND position = ?", 0, position).Asc("order", "id").Find(&menus)
+	return menus, err
+}
+
+func CountMenuChildren(id int64) (int64, error) {
+	return orm.Where("parent_id = ?", id).Count(new(Menu))
+}
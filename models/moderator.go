@@ -0,0 +1,159 @@
+package models
+
+import (
+	"time"
+
+	"github.com/missdeer/wego/modules/utils"
+)
+
+// moderation permissions, stored as bit flags
+type Permission int
+
+const (
+	PermEditPost Permission = 1 << iota
+	PermHidePost
+	PermLockPost
+	PermPinPost
+	PermMovePost
+	PermBestPost
+	PermEditComment
+	PermHideComment
+
+	PermAll = PermEditPost | PermHidePost | PermLockPost | PermPinPost |
+		PermMovePost | PermBestPost | PermEditComment | PermHideComment
+)
+
+func (p Permission) Has(perm Permission) bool {
+	return p&perm == perm
+}
+
+func (p Permission) CanEditPost() bool {
+	return p.Has(PermEditPost)
+}
+
+func (p Permission) CanHidePost() bool {
+	return p.Has(PermHidePost)
+}
+
+func (p Permission) CanLockPost() bool {
+	return p.Has(PermLockPost)
+}
+
+func (p Permission) CanPinPost() bool {
+	return p.Has(PermPinPost)
+}
+
+func (p Permission) CanMovePost() bool {
+	return p.Has(PermMovePost)
+}
+
+func (p Permission) CanBestPost() bool {
+	return p.Has(PermBestPost)
+}
+
+func (p Permission) CanEditComment() bool {
+	return p.Has(PermEditComment)
+}
+
+func (p Permission) CanHideComment() bool {
+	return p.Has(PermHideComment)
+}
+
+// any moderation permission
+func (p Permission) IsModerator() bool {
+	return p != 0
+}
+
+// moderator role of user
+// scope is a topic when TopicId is set, otherwise a category,
+// zero CategoryId and TopicId means the whole site
+type Moderator struct {
+	Id         int64
+	UserId     int64 `xorm:"index"`
+	CategoryId int64 `xorm:"index"`
+	TopicId    int64 `xorm:"index"`
+	Perms      Permission
+	Created    time.Time `xorm:"created"`
+	Updated    time.Time `xorm:"updated"`
+}
+
+func (m *Moderator) String() string {
+	return utils.ToStr(m.Id)
+}
+
+func (m *Moderator) User() *User {
+	return getUser(m.UserId)
+}
+
+func (m *Moderator) Category() *Category {
+	var category Category
+	has, err := orm.Id(m.CategoryId).Get(&category)
+	if err != nil || !has {
+		return nil
+	}
+	return &category
+}
+
+func (m *Moderator) Topic() *Topic {
+	var topic Topic
+	has, err := orm.Id(m.TopicId).Get(&topic)
+	if err != nil || !has {
+		return nil
+	}
+	return &topic
+}
+
+func FindModeratorsByUserId(userId int64) ([]Moderator, error) {
+	var moderators = make([]Moderator, 0)
+	err := orm.Find(&moderators, &Moderator{UserId: userId})
+	return moderators, err
+}
+
+// GetPermission returns the moderation permissions of user in the topic of category.
+// Administrators have all the permissions, forbidden users have none.
+func GetPermission(user *User, categoryId, topicId int64) Permission {
+	if user == nil || user.Id == 0 || user.IsForbid {
+		return 0
+	}
+	if user.IsAdmin {
+		return PermAll
+	}
+
+	var moderators = make([]Moderator, 0)
+	err := orm.Where("user_id = ?", user.Id).
+		And("(topic_id = 0 AND category_id IN (0, ?)) OR (topic_id > 0 AND topic_id = ?)", categoryId, topicId).
+		Find(&moderators)
+	if err != nil {
+		return 0
+	}
+
+	var perm Permission
+	for _, moderator := range moderators {
+		perm |= moderator.Perms
+	}
+	return perm
+}
+
+func GetPostPermission(user *User, post *Post) Permission {
+	return GetPermission(user, post.CategoryId, post.TopicId)
+}
+
+// CanViewPost checks if the user can see the post. Hidden posts are seen by
+// the author and the moderators who can hide them, scheduled posts by the
+// author and the moderators who can edit them.
+func CanViewPost(user *User, post *Post, perm Permission) bool {
+	isAuthor := user != nil && user.Id != 0 && user.Id == post.UserId
+	if post.IsScheduled {
+		return isAuthor || perm.CanEditPost()
+	}
+	return !post.IsHide || isAuthor || perm.CanHidePost()
+}
+
+// CanViewScheduledPostsOf checks if the user can see the scheduled posts of
+// the author in the lists of all categories.
+func CanViewScheduledPostsOf(user *User, authorId int64) bool {
+	if user == nil || user.Id == 0 {
+		return false
+	}
+	return user.Id == authorId || GetPermission(user, 0, 0).CanEditPost()
+}
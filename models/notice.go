@@ -1,7 +1,6 @@
 package models
 
 import (
-	"fmt"
 	"time"
 
 	"github.com/missdeer/wego/modules/utils"
@@ -29,7 +28,7 @@ func (m *Notification) String() string {
 }
 
 func (m *Notification) Link() string {
-	return fmt.Sprintf("%s%s#reply%d", setting.AppUrl, m.Uri, m.Floor)
+	return floorLink(setting.AppUrl+m.Uri, m.Floor)
 }
 
 func (m *Notification) GetContentCache() string {
@@ -75,3 +74,17 @@ func GetUnreadNotificationCount(userId int64) int64 {
 	})
 	return count
 }
+
+// notifications sent for the comment
+func FindCommentNotifications(comment *Comment) ([]*Notification, error) {
+	var notifications = make([]*Notification, 0)
+	err := orm.Where("target_id = ? AND floor = ? AND from_user_id = ? AND action = ?",
+		comment.PostId, comment.Floor, comment.UserId, setting.NOTICE_TYPE_COMMENT).Find(&notifications)
+	return notifications, err
+}
+
+func DeleteCommentNotifications(comment *Comment) error {
+	_, err := orm.Where("target_id = ? AND floor = ? AND from_user_id = ? AND action = ?",
+		comment.PostId, comment.Floor, comment.UserId, setting.NOTICE_TYPE_COMMENT).Delete(new(Notification))
+	return err
+}
@@ -1,16 +1,43 @@
 package models
 
-import "time"
+import (
+	"errors"
+	"strings"
+	"time"
+	"unicode"
 
+	"github.com/missdeer/wego/modules/utils"
+	"github.com/missdeer/wego/setting"
+)
+
+// the uri of the new page is taken by another one
+var ErrPageExist = errors.New("page already exists")
+
+// protection levels of wiki pages
+const (
+	// active users above the edit reputation can edit
+	PageProtectNone = iota
+	// active users above the protected reputation can edit
+	PageProtectSemi
+	// admins only
+	PageProtectFull
+)
+
+// IsWiki: page can be edited by the users of the protection level
+// ParentId: the parent page, pages are listed under it by Order
 type Page struct {
 	Id           int64
+	ParentId     int64     `xorm:"index"`
+	Order        int       `xorm:"index"`
 	UserId       int64     `xorm:"index"`
-	Uri          string    `xorm:"varchar(60) unqiue"`
+	Uri          string    `xorm:"varchar(60) unique"`
 	Title        string    `xorm:"varchar(60)"`
 	Content      string    `xorm:"text"`
 	ContentCache string    `xorm:"text"`
 	LastAuthorId int64     `xorm:"index"`
 	IsPublish    bool      `xorm:"index"`
+	IsWiki       bool      `xorm:"index"`
+	Protection   int       ``
 	Created      time.Time `xorm:"created"`
 	Updated      time.Time `xorm:"updated"`
 }
@@ -23,6 +50,64 @@ func (p *Page) LastAuthor() *User {
 	return getUser(p.LastAuthorId)
 }
 
+func (p *Page) GetTitle() string {
+	return p.Title
+}
+
+func (p *Page) GetContentCache() string {
+	if setting.RealtimeRenderMD {
+		return utils.RenderMarkdown(p.Content)
+	}
+	return p.ContentCache
+}
+
+func (p *Page) Link() string {
+	return setting.AppUrl + strings.TrimPrefix(p.Uri, "/")
+}
+
+// CanEdit reports if the user can edit the page, admins can edit every
+// page and the others edit the wiki pages of their protection level.
+func (p *Page) CanEdit(user *User) bool {
+	if user == nil || user.Id == 0 || user.IsForbid {
+		return false
+	}
+	if user.IsAdmin {
+		return true
+	}
+	if !p.IsWiki || !user.IsActive {
+		return false
+	}
+	switch p.Protection {
+	case PageProtectNone:
+		return user.Reputation >= setting.WikiEditReputation
+	case PageProtectSemi:
+		return user.Reputation >= setting.WikiProtectedReputation
+	}
+	return false
+}
+
+// CanCreateWikiPage reports if the user can create new wiki pages.
+func CanCreateWikiPage(user *User) bool {
+	page := Page{IsWiki: true, Protection: PageProtectNone}
+	return page.CanEdit(user)
+}
+
+// WikiPageUri returns the uri of the wiki page of title, the title is
+// lowercased and the spaces are replaced by dashes.
+func WikiPageUri(title string) string {
+	words := strings.FieldsFunc(strings.ToLower(title), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '-' && r != '_' && r != '.'
+	})
+	slug := []rune(strings.Trim(strings.Join(words, "-"), "-."))
+	if len(slug) > 54 {
+		slug = slug[:54]
+	}
+	if len(slug) == 0 {
+		return ""
+	}
+	return "/page/" + string(slug)
+}
+
 func GetPage(isPublish bool, uri string) (*Page, error) {
 	var page = Page{
 		IsPublish: isPublish,
@@ -37,3 +122,141 @@ func GetPage(isPublish bool, uri string) (*Page, error) {
 	}
 	return &page, nil
 }
+
+// FindPublishedPageUris returns the uris of the published pages, the links
+// to the other ones are shown as missing.
+func FindPublishedPageUris(uris []string) (map[string]bool, error) {
+	found := make(map[string]bool)
+	if len(uris) == 0 {
+		return found, nil
+	}
+
+	var pages = make([]Page, 0)
+	if err := orm.In("uri", uris).And("is_publish = ?", true).Cols("uri").Find(&pages); err != nil {
+		return nil, err
+	}
+	for _, page := range pages {
+		found[page.Uri] = true
+	}
+	return found, nil
+}
+
+// max depth of page tree
+const pageMaxDepth = 8
+
+// FindPageAncestors returns the published parent pages of page from the root
+// one, the pages above an unpublished parent are not shown like FindPageTree.
+func FindPageAncestors(page *Page) []*Page {
+	return findPageAncestors(page, true)
+}
+
+func findPageAncestors(page *Page, publishedOnly bool) []*Page {
+	var ancestors []*Page
+	seen := map[int64]bool{page.Id: true}
+	for parentId := page.ParentId; parentId > 0 && !seen[parentId] && len(ancestors) < pageMaxDepth; {
+		var parent Page
+		if has, err := orm.Id(parentId).Get(&parent); err != nil || !has {
+			break
+		}
+		if publishedOnly && !parent.IsPublish {
+			break
+		}
+		seen[parentId] = true
+		ancestors = append([]*Page{&parent}, ancestors...)
+		parentId = parent.ParentId
+	}
+	return ancestors
+}
+
+// IsPageAncestor reports if the page of id is the page of pageId or one of its parents.
+func IsPageAncestor(id, pageId int64) bool {
+	if id == pageId {
+		return true
+	}
+	page := Page{Id: pageId}
+	if has, err := orm.Id(pageId).Get(&page); err != nil || !has {
+		return false
+	}
+	for _, ancestor := range findPageAncestors(&page, false) {
+		if ancestor.Id == id {
+			return true
+		}
+	}
+	return false
+}
+
+// page in the table of contents
+type PageNode struct {
+	*Page
+	Depth int
+}
+
+// FindPageTree returns the published pages under root in the order of the
+// table of contents, root itself is the first one.
+func FindPageTree(root *Page) ([]*PageNode, error) {
+	children := make(map[int64][]*Page)
+	seen := map[int64]bool{root.Id: true}
+	parentIds := []int64{root.Id}
+	for depth := 0; depth < pageMaxDepth && len(parentIds) > 0; depth++ {
+		var pages = make([]Page, 0)
+		if err := orm.In("parent_id", parentIds).And("is_publish = ?", true).
+			Asc("order", "id").Find(&pages); err != nil {
+			return nil, err
+		}
+		parentIds = parentIds[:0]
+		for i := range pages {
+			page := &pages[i]
+			if seen[page.Id] {
+				continue
+			}
+			seen[page.Id] = true
+			children[page.ParentId] = append(children[page.ParentId], page)
+			parentIds = append(parentIds, page.Id)
+		}
+	}
+
+	var nodes []*PageNode
+	var walk func(page *Page, depth int)
+	walk = func(page *Page, depth int) {
+		nodes = append(nodes, &PageNode{page, depth})
+		for _, child := range children[page.Id] {
+			walk(child, depth+1)
+		}
+	}
+	walk(root, 0)
+	return nodes, nil
+}
+
+// CreateWikiPage creates the wiki page and its first revision.
+func CreateWikiPage(page *Page, userId int64, reason string) error {
+	page.UserId = userId
+	page.LastAuthorId = userId
+	page.IsWiki = true
+	page.IsPublish = true
+	if _, err := orm.Insert(page); err != nil {
+		// the uri is unique, the page was created by another request
+		if cnt, _ := orm.Where("uri = ?", page.Uri).Count(new(Page)); cnt > 0 {
+			return ErrPageExist
+		}
+		return err
+	}
+	return AddPageRevision(nil, page, userId, reason)
+}
+
+// DeletePage deletes the page with its revisions.
+func DeletePage(page *Page) error {
+	sess := orm.NewSession()
+	defer sess.Close()
+	if err := sess.Begin(); err != nil {
+		return err
+	}
+	if _, err := sess.Id(page.Id).Delete(new(Page)); err != nil {
+		sess.Rollback()
+		return err
+	}
+	if _, err := sess.Where("page_id = ?", page.Id).Delete(new(PageRevision)); err != nil {
+		sess.Rollback()
+		return err
+	}
+	return sess.Commit()
+}
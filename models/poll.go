@@ -0,0 +1,261 @@
+package models
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/missdeer/wego/modules/utils"
+)
+
+var (
+	ErrPollClosed = errors.New("poll is closed")
+	ErrPollVoted  = errors.New("already voted the poll")
+	ErrPollChoice = errors.New("invalid poll choice")
+)
+
+// poll attached to post, zero Closed means the poll never closes
+type Poll struct {
+	Id       int64
+	PostId   int64 `xorm:"unique"`
+	Multiple bool
+	// results are visible before voting
+	ShowResults bool
+	Voters      int
+	Closed      time.Time
+	Created     time.Time `xorm:"created"`
+	Updated     time.Time `xorm:"updated"`
+}
+
+func (m *Poll) String() string {
+	return utils.ToStr(m.Id)
+}
+
+func (m *Poll) IsClosed() bool {
+	return !m.Closed.IsZero() && m.Closed.Before(time.Now())
+}
+
+func (m *Poll) Options() []PollOption {
+	options, _ := FindPollOptions(m.Id)
+	return options
+}
+
+// results are visible after voting, when the poll is closed, or at once if the poll allows
+func (m *Poll) CanShowResults(voted bool) bool {
+	return m.ShowResults || voted || m.IsClosed()
+}
+
+type PollOption struct {
+	Id     int64
+	PollId int64  `xorm:"index"`
+	Title  string `xorm:"varchar(100)"`
+	Order  int
+	Votes  int
+}
+
+// percentage of voters who chose the option
+func (m *PollOption) Percent(voters int) int {
+	if voters == 0 {
+		return 0
+	}
+	return m.Votes * 100 / voters
+}
+
+// vote of user on a poll, one for each user, Options are the comma separated chosen option ids
+type PollVote struct {
+	Id      int64
+	PollId  int64     `xorm:"unique(poll_vote)"`
+	UserId  int64     `xorm:"unique(poll_vote)"`
+	Options string    `xorm:"varchar(255)"`
+	Created time.Time `xorm:"created"`
+}
+
+// chosen option ids of the vote
+func (m *PollVote) OptionIds() []int64 {
+	var ids []int64
+	for _, s := range strings.Split(m.Options, ",") {
+		if id, err := utils.StrTo(s).Int64(); err == nil {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+func GetPollByPostId(postId int64) (*Poll, error) {
+	var poll Poll
+	has, err := orm.Where("post_id = ?", postId).Get(&poll)
+	if err != nil {
+		return nil, err
+	}
+	if !has {
+		return nil, ErrNotExist
+	}
+	return &poll, nil
+}
+
+func (p *Post) Poll() *Poll {
+	poll, err := GetPollByPostId(p.Id)
+	if err != nil {
+		return nil
+	}
+	return poll
+}
+
+func FindPollOptions(pollId int64) ([]PollOption, error) {
+	var options = make([]PollOption, 0)
+	err := orm.Where("poll_id = ?", pollId).Asc("order").Find(&options)
+	return options, err
+}
+
+func GetPollVote(pollId, userId int64) (*PollVote, error) {
+	var vote PollVote
+	has, err := orm.Where("poll_id = ? AND user_id = ?", pollId, userId).Get(&vote)
+	if err != nil {
+		return nil, err
+	}
+	if !has {
+		return nil, ErrNotExist
+	}
+	return &vote, nil
+}
+
+// SavePoll attaches the poll with options to post or updates the existing one,
+// options of a poll can't be changed after the first vote. Empty options
+// remove the poll if nobody has voted.
+func SavePoll(post *Post, poll *Poll, titles []string) error {
+	old, err := GetPollByPostId(post.Id)
+	if err != nil && err != ErrNotExist {
+		return err
+	}
+
+	sess := orm.NewSession()
+	defer sess.Close()
+	if err := sess.Begin(); err != nil {
+		return err
+	}
+
+	if old != nil {
+		poll.Id = old.Id
+		if old.Voters > 0 {
+			// only the settings of a voted poll can be changed
+			titles = nil
+		} else if _, err := sess.Where("poll_id = ?", old.Id).Delete(new(PollOption)); err != nil {
+			sess.Rollback()
+			return err
+		}
+
+		if len(titles) == 0 && old.Voters == 0 {
+			if _, err := sess.Id(old.Id).Delete(new(Poll)); err != nil {
+				sess.Rollback()
+				return err
+			}
+			return sess.Commit()
+		}
+
+		if _, err := sess.Id(old.Id).Cols("multiple", "show_results", "closed", "updated").Update(poll); err != nil {
+			sess.Rollback()
+			return err
+		}
+	} else {
+		if len(titles) == 0 {
+			return nil
+		}
+		poll.PostId = post.Id
+		if _, err := sess.Insert(poll); err != nil {
+			sess.Rollback()
+			return err
+		}
+	}
+
+	for i, title := range titles {
+		option := PollOption{PollId: poll.Id, Title: title, Order: i}
+		if _, err := sess.Insert(&option); err != nil {
+			sess.Rollback()
+			return err
+		}
+	}
+	return sess.Commit()
+}
+
+// VotePoll saves the choices of user on the poll, every user votes once.
+func VotePoll(poll *Poll, userId int64, optionIds []int64) error {
+	if poll.IsClosed() {
+		return ErrPollClosed
+	}
+	if len(optionIds) == 0 || (!poll.Multiple && len(optionIds) > 1) {
+		return ErrPollChoice
+	}
+
+	// choices must be distinct options of the poll
+	valid := make(map[int64]bool)
+	for _, option := range poll.Options() {
+		valid[option.Id] = true
+	}
+	ids := make([]string, 0, len(optionIds))
+	for _, id := range optionIds {
+		if !valid[id] {
+			return ErrPollChoice
+		}
+		valid[id] = false
+		ids = append(ids, utils.ToStr(id))
+	}
+
+	sess := orm.NewSession()
+	defer sess.Close()
+	if err := sess.Begin(); err != nil {
+		return err
+	}
+
+	// the unique index rejects a second vote of user
+	vote := PollVote{PollId: poll.Id, UserId: userId, Options: strings.Join(ids, ",")}
+	if _, err := sess.Insert(&vote); err != nil {
+		sess.Rollback()
+		if _, err := GetPollVote(poll.Id, userId); err == nil {
+			return ErrPollVoted
+		}
+		return err
+	}
+
+	if _, err := sess.In("id", optionIds).Incr("votes").Update(new(PollOption)); err != nil {
+		sess.Rollback()
+		return err
+	}
+	if _, err := sess.Id(poll.Id).Incr("voters").Update(new(Poll)); err != nil {
+		sess.Rollback()
+		return err
+	}
+	if err := sess.Commit(); err != nil {
+		return err
+	}
+	poll.Voters++
+	return nil
+}
+
+// DeletePoll deletes the poll of post with its options and votes.
+func DeletePoll(post *Post) error {
+	poll, err := GetPollByPostId(post.Id)
+	if err == ErrNotExist {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	sess := orm.NewSession()
+	defer sess.Close()
+	if err := sess.Begin(); err != nil {
+		return err
+	}
+	if _, err := sess.Where("poll_id = ?", poll.Id).Delete(new(PollVote)); err != nil {
+		sess.Rollback()
+		return err
+	}
+	if _, err := sess.Where("poll_id = ?", poll.Id).Delete(new(PollOption)); err != nil {
+		sess.Rollback()
+		return err
+	}
+	if _, err := sess.Id(poll.Id).Delete(new(Poll)); err != nil {
+		sess.Rollback()
+		return err
+	}
+	return sess.Commit()
+}
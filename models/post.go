@@ -6,30 +6,44 @@ import (
 	"time"
 
 	"github.com/Unknwon/i18n"
+	"github.com/go-xorm/xorm"
 	"github.com/missdeer/wego/modules/utils"
 	"github.com/missdeer/wego/setting"
 )
 
 // post content
 type Post struct {
-	Id           int64
-	UserId       int64  `xorm:"index"`
-	Title        string `xorm:"varchar(60)"`
-	Content      string `xorm:"text"`
-	ContentCache string `xorm:"text"`
-	Browsers     int    `xorm:"index"`
-	Replys       int    `xorm:"index"`
-	Favorites    int    `xorm:"index"`
-	LastReplyId  int64
-	LastAuthorId int64
-	TopicId      int64     `xorm:"index"`
-	Lang         int       `xorm:"index"`
-	IsBest       bool      `xorm:"index"`
-	CanEdit      bool      `xorm:"index"`
-	CategoryId   int64     `xorm:"index"`
-	Created      time.Time `xorm:"created"`
-	Updated      time.Time `xorm:"updated"`
-	LastReplied  time.Time `xorm:"updated"`
+	Id            int64
+	UserId        int64  `xorm:"index"`
+	Title         string `xorm:"varchar(60)"`
+	Content       string `xorm:"text"`
+	ContentCache  string `xorm:"text"`
+	Browsers      int    `xorm:"index"`
+	Replys        int    `xorm:"index"`
+	Favorites     int    `xorm:"index"`
+	LastReplyId   int64
+	LastAuthorId  int64
+	TopicId       int64     `xorm:"index"`
+	Lang          int       `xorm:"index"`
+	IsBest        bool      `xorm:"index"`
+	IsHide        bool      `xorm:"index"`
+	IsLock        bool      `xorm:"index"`
+	Sticky        int       `xorm:"index"`
+	StickyExpired time.Time `xorm:"index"`
+	RedirectId    int64     `xorm:"index"`
+	EditTimes     int
+	Score         int   `xorm:"index"`
+	CanEdit       bool  `xorm:"index"`
+	CategoryId    int64 `xorm:"index"`
+	AnswerId      int64 `xorm:"index"`
+	// the last floor allocated to the comments
+	Floors int
+	// scheduled posts are published at PublishAt by the scheduler
+	IsScheduled bool      `xorm:"index"`
+	PublishAt   time.Time `xorm:"index"`
+	Created     time.Time `xorm:"created"`
+	Updated     time.Time `xorm:"updated"`
+	LastReplied time.Time `xorm:"updated"`
 }
 
 func (m *Post) String() string {
@@ -44,6 +58,38 @@ func (m *Post) Path() string {
 	return fmt.Sprintf("/post/%d", m.Id)
 }
 
+// page of post which lists the comment of floor
+func FloorPage(floor int) int {
+	if floor <= 0 || setting.CommentCountPerPage <= 0 {
+		return 1
+	}
+	return (floor-1)/setting.CommentCountPerPage + 1
+}
+
+// link of the floor on the page which lists it
+func floorLink(link string, floor int) string {
+	if floor <= 0 {
+		return link
+	}
+	if page := FloorPage(floor); page > 1 {
+		return fmt.Sprintf("%s?p=%d#reply%d", link, page, floor)
+	}
+	return fmt.Sprintf("%s#reply%d", link, floor)
+}
+
+func (m *Post) FloorLink(floor int) string {
+	return floorLink(m.Link(), floor)
+}
+
+// link of the latest comment
+func (m *Post) LatestLink() string {
+	floor := m.Floors
+	if floor == 0 {
+		floor = m.Replys
+	}
+	return m.FloorLink(floor)
+}
+
 func (m *Post) GetContentCache() string {
 	if setting.RealtimeRenderMD {
 		return utils.RenderMarkdown(m.Content)
@@ -52,6 +98,18 @@ func (m *Post) GetContentCache() string {
 	}
 }
 
+func (m *Post) IsSticky() bool {
+	if m.Sticky == setting.STICKY_NONE {
+		return false
+	}
+	return m.StickyExpired.IsZero() || m.StickyExpired.After(time.Now())
+}
+
+// check if post sorts first in the listing of level
+func (m *Post) IsStickyIn(level int) bool {
+	return m.IsSticky() && m.Sticky >= level
+}
+
 func (m *Post) GetLang() string {
 	return i18n.GetLangByIndex(m.Lang)
 }
@@ -92,6 +150,23 @@ func getUser(id int64) *User {
 	return &user
 }
 
+// question is solved when a comment is accepted as the answer
+func (p *Post) IsSolved() bool {
+	return p.AnswerId > 0
+}
+
+// the accepted answer of question, deleted or moved comments are not an answer
+func (p *Post) Answer() *Comment {
+	if p.AnswerId == 0 {
+		return nil
+	}
+	comment, err := GetCommentById(p.AnswerId)
+	if err != nil || comment.IsDelete || comment.PostId != p.Id {
+		return nil
+	}
+	return comment
+}
+
 func (p *Post) User() *User {
 	return getUser(p.UserId)
 }
@@ -136,38 +211,208 @@ func GetPostById(id int64) (*Post, error) {
 	return &post, nil
 }
 
+// FindPostTitles returns the titles of posts by id which the user can see,
+// they are shown on the links to posts in markdown.
+func FindPostTitles(user *User, ids []int64) (map[int64]string, error) {
+	titles := make(map[int64]string)
+	if len(ids) == 0 {
+		return titles, nil
+	}
+
+	var posts = make([]Post, 0)
+	err := orm.In("id", ids).Cols("id", "user_id", "category_id", "topic_id", "title", "is_hide", "is_scheduled").Find(&posts)
+	if err != nil {
+		return nil, err
+	}
+	for i := range posts {
+		post := &posts[i]
+		if (post.IsHide || post.IsScheduled) && !CanViewPost(user, post, GetPostPermission(user, post)) {
+			continue
+		}
+		titles[post.Id] = post.Title
+	}
+	return titles, nil
+}
+
+// count posts which are not hidden
+func CountPostsByExample(example *Post) (int64, error) {
+	return CountPostsByTag(example, 0, nil)
+}
+
+// session of the posts which are published and not hidden, filtered by tag
+// if tagId is set and by the languages if langs are set
+func listPosts(tagId int64, langs []int) *xorm.Session {
+	s := orm.Where("is_hide = ? AND is_scheduled = ?", false, false)
+	if tagId > 0 {
+		s.And("id IN (SELECT post_id FROM post_tag WHERE tag_id = ?)", tagId)
+	}
+	if len(langs) > 0 {
+		s.In("lang", langs)
+	}
+	return s
+}
+
+func CountPostsByTag(example *Post, tagId int64, langs []int) (int64, error) {
+	return listPosts(tagId, langs).Count(example)
+}
+
+// sticky level of the listing which is filtered by example
+func stickyLevel(example *Post) int {
+	if example.TopicId > 0 {
+		return setting.STICKY_TOPIC
+	}
+	if example.CategoryId > 0 {
+		return setting.STICKY_CATEGORY
+	}
+	return setting.STICKY_GLOBAL
+}
+
+// sticky posts of the listing sort first
+func stickyOrder(example *Post) string {
+	return fmt.Sprintf("(sticky >= %d) DESC", stickyLevel(example))
+}
+
 func FindPosts(limit, start int) ([]Post, error) {
+	return FindPostsByExample(&Post{}, limit, start)
+}
+
+func FindPostsByExample(example *Post, limit, start int) ([]Post, error) {
+	return FindPostsByTag(example, 0, nil, limit, start)
+}
+
+func FindPostsByTag(example *Post, tagId int64, langs []int, limit, start int) ([]Post, error) {
 	var posts = make([]Post, 0)
-	err := orm.Desc("last_replied").Limit(limit, start).Find(&posts)
+	err := listPosts(tagId, langs).OrderBy(stickyOrder(example)).
+		Desc("last_replied").Limit(limit, start).Find(&posts, example)
 	return posts, err
 }
 
 func RecentPosts(sort string, limit, start int) ([]Post, error) {
+	return RecentPostsByExample(sort, &Post{}, limit, start)
+}
+
+func RecentPostsByExample(sort string, example *Post, limit, start int) ([]Post, error) {
+	return RecentPostsByTag(sort, example, 0, nil, limit, start)
+}
+
+func RecentPostsByTag(sort string, example *Post, tagId int64, langs []int, limit, start int) ([]Post, error) {
 	var posts = make([]Post, 0)
-	s := orm.Limit(limit, start)
+	s := listPosts(tagId, langs).OrderBy(stickyOrder(example)).Limit(limit, start)
 	switch sort {
 	case "recent":
 		s.Desc("created")
 	case "hot":
 		s.Desc("last_replied")
 	case "cold":
-		s.Where("Replys = ?", 0).Desc("created")
+		s.And("replys = ?", 0).Desc("created")
+	case "unanswered":
+		// no comment accepted or voted up
+		s.And("answer_id = ?", 0).
+			And("id NOT IN (SELECT post_id FROM comment WHERE score > 0 AND is_delete = ?)", false).Desc("created")
+	case "unsolved":
+		s.And("answer_id = ?", 0).Desc("created")
 	default:
 		return nil, errors.New("unknown sort")
 	}
-	err := s.Find(&posts)
+	err := s.Find(&posts, example)
 	return posts, err
 }
 
-func NewBestPostsByExample(posts *[]Post, example *Post) error {
-	return orm.Where("is_best = ?", true).Desc("created").Limit(10).Find(posts, example)
+// sorts which are only for the posts of Q&A categories
+func IsQuestionSort(sort string) bool {
+	return sort == "unanswered" || sort == "unsolved"
+}
+
+// AcceptAnswer marks the comment as the accepted answer of post,
+// nil comment clears the accepted answer.
+func AcceptAnswer(post *Post, comment *Comment) error {
+	post.AnswerId = 0
+	if comment != nil {
+		post.AnswerId = comment.Id
+	}
+	_, err := orm.Id(post.Id).Cols("answer_id").NoAutoTime().Update(post)
+	return err
 }
 
-func MostReplysPostsByExample(posts *[]Post, example *Post) error {
-	return orm.Where("replys > 0").Desc("created", "replys").Limit(10).Find(posts, example)
+func NewBestPostsByExample(posts *[]Post, example *Post, langs []int) error {
+	return listPosts(0, langs).And("is_best = ?", true).Desc("created").Limit(10).Find(posts, example)
+}
+
+func MostReplysPostsByExample(posts *[]Post, example *Post, langs []int) error {
+	return listPosts(0, langs).And("replys > 0").Desc("created", "replys").Limit(10).Find(posts, example)
 }
 
 func UpdatePostBrowsersById(id int64) error {
 	_, err := orm.Id(id).Incr("browsers").Update(new(Post))
 	return err
 }
+
+// posts of user, the scheduled ones are included for the author and the
+// moderators, the hidden ones for the author and the admins
+func userPosts(userId int64, withScheduled, withHidden bool) *xorm.Session {
+	s := orm.Where("user_id = ?", userId)
+	if !withScheduled {
+		s.And("is_scheduled = ?", false)
+	}
+	if !withHidden {
+		s.And("is_hide = ?", false)
+	}
+	return s
+}
+
+func CountPostsByUser(userId int64, withScheduled, withHidden bool) (int64, error) {
+	return userPosts(userId, withScheduled, withHidden).Count(new(Post))
+}
+
+func FindPostsByUser(userId int64, withScheduled, withHidden bool, limit, start int) ([]Post, error) {
+	var posts = make([]Post, 0)
+	err := userPosts(userId, withScheduled, withHidden).Desc("created").Limit(limit, start).Find(&posts)
+	return posts, err
+}
+
+// scheduled posts which are due to publish
+func FindDuePosts(now time.Time) ([]Post, error) {
+	var posts = make([]Post, 0)
+	err := orm.Where("is_scheduled = ? AND publish_at <= ?", true, now).Asc("publish_at").Find(&posts)
+	return posts, err
+}
+
+// PublishPost publishes the scheduled post as a new post, false is returned
+// if the post was published already.
+func PublishPost(post *Post) (bool, error) {
+	now := time.Now()
+	// created is written by hand, xorm does not update the created columns
+	res, err := orm.Exec("UPDATE post SET is_scheduled = ?, created = ?, last_replied = ? WHERE id = ? AND is_scheduled = ?",
+		false, now, now, post.Id, true)
+	if err != nil {
+		return false, err
+	}
+	affected, err := res.RowsAffected()
+	if err != nil || affected == 0 {
+		return false, err
+	}
+	post.IsScheduled = false
+	post.Created = now
+	post.LastReplied = now
+	return true, nil
+}
+
+// unpin the sticky posts which are expired
+func ExpireStickyPosts() error {
+	var posts = make([]Post, 0)
+	if err := orm.Where("sticky > ?", setting.STICKY_NONE).Find(&posts); err != nil {
+		return err
+	}
+	for _, post := range posts {
+		if post.IsSticky() {
+			continue
+		}
+		post.Sticky = setting.STICKY_NONE
+		post.StickyExpired = time.Time{}
+		// the last replied time is kept, expired posts don't go up the lists
+		if _, err := orm.Id(post.Id).Cols("sticky", "sticky_expired").NoAutoTime().Update(&post); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,187 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// last floor of post read by user, only the visited posts have a row
+type PostRead struct {
+	Id      int64
+	UserId  int64 `xorm:"unique(post_read)"`
+	PostId  int64 `xorm:"unique(post_read)"`
+	Floor   int
+	Updated time.Time `xorm:"updated"`
+}
+
+// all posts of category are read up to the time, which stands for the
+// rows of posts so marking a category as read writes a single row
+type CategoryRead struct {
+	Id         int64
+	UserId     int64 `xorm:"unique(category_read)"`
+	CategoryId int64 `xorm:"unique(category_read)"`
+	Read       time.Time
+}
+
+// read state of post for user
+type ReadState struct {
+	// never read post
+	IsNew bool
+	// replies after the last read floor
+	NewReplies int
+	// first floor not read yet
+	FirstUnread int
+}
+
+// MarkPostRead records the user has read post up to floor, the last read
+// floor never goes back.
+func MarkPostRead(userId, postId int64, floor int) error {
+	var read PostRead
+	has, err := orm.Where("user_id = ? AND post_id = ?", userId, postId).Get(&read)
+	if err != nil {
+		return err
+	}
+	if !has {
+		read = PostRead{UserId: userId, PostId: postId, Floor: floor}
+		if _, err := orm.Insert(&read); err == nil {
+			return nil
+		}
+		// inserted by a concurrent view, update it as below
+	}
+	if has && read.Floor >= floor {
+		return nil
+	}
+	_, err = orm.Where("user_id = ? AND post_id = ? AND floor < ?", userId, postId, floor).
+		Cols("floor", "updated").Update(&PostRead{Floor: floor})
+	return err
+}
+
+// MarkCategoryRead marks all posts of category as read for user, the read
+// rows of the posts are replaced by the time of category.
+func MarkCategoryRead(userId, categoryId int64) error {
+	sess := orm.NewSession()
+	defer sess.Close()
+	if err := sess.Begin(); err != nil {
+		return err
+	}
+
+	if _, err := sess.Where("user_id = ? AND post_id IN (SELECT id FROM post WHERE category_id = ?)", userId, categoryId).
+		Delete(new(PostRead)); err != nil {
+		sess.Rollback()
+		return err
+	}
+
+	var read CategoryRead
+	has, err := sess.Where("user_id = ? AND category_id = ?", userId, categoryId).Get(&read)
+	if err != nil {
+		sess.Rollback()
+		return err
+	}
+	read.Read = time.Now()
+	if has {
+		_, err = sess.Id(read.Id).Cols("read").Update(&read)
+	} else {
+		read.UserId = userId
+		read.CategoryId = categoryId
+		_, err = sess.Insert(&read)
+	}
+	if err != nil {
+		sess.Rollback()
+		return err
+	}
+	return sess.Commit()
+}
+
+// DeletePostReads deletes the read rows of post.
+func DeletePostReads(post *Post) error {
+	_, err := orm.Where("post_id = ?", post.Id).Delete(new(PostRead))
+	return err
+}
+
+// replies of post after a time
+type floorCount struct {
+	PostId int64
+	First  int
+	Cnt    int
+}
+
+// count the replies of posts after the time
+func countRepliesAfter(postIds []int64, after time.Time) ([]floorCount, error) {
+	args := make([]interface{}, 0, len(postIds)+1)
+	marks := make([]string, 0, len(postIds))
+	for _, id := range postIds {
+		args = append(args, id)
+		marks = append(marks, "?")
+	}
+	args = append(args, after)
+
+	var counts = make([]floorCount, 0)
+	err := orm.Sql(fmt.Sprintf("SELECT post_id, MIN(floor) AS first, COUNT(*) AS cnt FROM %s "+
+		"WHERE post_id IN (%s) AND created > ? GROUP BY post_id", orm.Quote("comment"), strings.Join(marks, ",")), args...).Find(&counts)
+	return counts, err
+}
+
+// FindReadStates returns the read states of the posts which have something
+// unread for user, post id => state.
+func FindReadStates(userId int64, posts []Post) (map[int64]*ReadState, error) {
+	states := make(map[int64]*ReadState)
+	if userId == 0 || len(posts) == 0 {
+		return states, nil
+	}
+
+	postIds := make([]int64, 0, len(posts))
+	for _, post := range posts {
+		postIds = append(postIds, post.Id)
+	}
+
+	var reads = make([]PostRead, 0)
+	if err := orm.Where("user_id = ?", userId).In("post_id", postIds).Find(&reads); err != nil {
+		return nil, err
+	}
+	floors := make(map[int64]int, len(reads))
+	for _, read := range reads {
+		floors[read.PostId] = read.Floor
+	}
+
+	var cats = make([]CategoryRead, 0)
+	if err := orm.Where("user_id = ?", userId).Find(&cats); err != nil {
+		return nil, err
+	}
+	marks := make(map[int64]time.Time, len(cats))
+	for _, cat := range cats {
+		marks[cat.CategoryId] = cat.Read
+	}
+
+	// posts read by marking the category, grouped by the time of category
+	marked := make(map[int64][]int64)
+	for _, post := range posts {
+		if floor, ok := floors[post.Id]; ok {
+			if post.Floors > floor {
+				states[post.Id] = &ReadState{NewReplies: post.Floors - floor, FirstUnread: floor + 1}
+			}
+			continue
+		}
+
+		mark, ok := marks[post.CategoryId]
+		switch {
+		case ok && !post.LastReplied.After(mark):
+			// read when the category was marked
+		case ok && !post.Created.After(mark):
+			marked[post.CategoryId] = append(marked[post.CategoryId], post.Id)
+		default:
+			states[post.Id] = &ReadState{IsNew: true, NewReplies: post.Floors, FirstUnread: 1}
+		}
+	}
+
+	for categoryId, ids := range marked {
+		counts, err := countRepliesAfter(ids, marks[categoryId])
+		if err != nil {
+			return nil, err
+		}
+		for _, count := range counts {
+			states[count.PostId] = &ReadState{NewReplies: count.Cnt, FirstUnread: count.First}
+		}
+	}
+	return states, nil
+}
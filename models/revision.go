@@ -0,0 +1,209 @@
+package models
+
+import (
+	"time"
+
+	"github.com/missdeer/wego/modules/utils"
+)
+
+// revision of post content, the first one is the original post
+type PostRevision struct {
+	Id      int64
+	PostId  int64     `xorm:"index"`
+	UserId  int64     `xorm:"index"`
+	Title   string    `xorm:"varchar(60)"`
+	Content string    `xorm:"text"`
+	Reason  string    `xorm:"varchar(255)"`
+	Created time.Time `xorm:"created"`
+}
+
+func (m *PostRevision) String() string {
+	return utils.ToStr(m.Id)
+}
+
+func (m *PostRevision) User() *User {
+	return getUser(m.UserId)
+}
+
+// UpdatePostRevision saves the cols of post and stores the edit as a revision
+// in one transaction, original is the post before edit. The original post is
+// stored as the first revision if it hasn't been.
+func UpdatePostRevision(original, post *Post, userId int64, reason string, cols ...string) error {
+	sess := orm.NewSession()
+	defer sess.Close()
+	if err := sess.Begin(); err != nil {
+		return err
+	}
+
+	if _, err := sess.Id(post.Id).Cols(cols...).Update(post); err != nil {
+		sess.Rollback()
+		return err
+	}
+
+	cnt, err := sess.Count(&PostRevision{PostId: post.Id})
+	if err != nil {
+		sess.Rollback()
+		return err
+	}
+	if cnt == 0 {
+		first := PostRevision{
+			PostId:  original.Id,
+			UserId:  original.UserId,
+			Title:   original.Title,
+			Content: original.Content,
+			Created: original.Created,
+		}
+		if _, err := sess.NoAutoTime().Insert(&first); err != nil {
+			sess.Rollback()
+			return err
+		}
+	}
+
+	revision := PostRevision{
+		PostId:  post.Id,
+		UserId:  userId,
+		Title:   post.Title,
+		Content: post.Content,
+		Reason:  reason,
+	}
+	if _, err := sess.Insert(&revision); err != nil {
+		sess.Rollback()
+		return err
+	}
+
+	if _, err := sess.Id(post.Id).NoAutoTime().Incr("edit_times").Update(new(Post)); err != nil {
+		sess.Rollback()
+		return err
+	}
+	post.EditTimes++
+	return sess.Commit()
+}
+
+func CountPostRevisions(postId int64) (int64, error) {
+	return orm.Count(&PostRevision{PostId: postId})
+}
+
+// FindPostRevisionsDesc returns a page of revisions from the newest one,
+// with one more revision which the last one of the page is changed from.
+func FindPostRevisionsDesc(postId int64, limit, start int) ([]PostRevision, error) {
+	var revisions = make([]PostRevision, 0)
+	err := orm.Where("post_id = ?", postId).Desc("id").Limit(limit+1, start).Find(&revisions)
+	return revisions, err
+}
+
+// PostRevisionNumber returns the number of revision in the revisions of its
+// post, the original post is the first one.
+func PostRevisionNumber(revision *PostRevision) (int64, error) {
+	return orm.Where("post_id = ? AND id <= ?", revision.PostId, revision.Id).Count(new(PostRevision))
+}
+
+func GetPostRevision(postId, id int64) (*PostRevision, error) {
+	var revision = PostRevision{Id: id, PostId: postId}
+	if err := GetByExample(&revision); err != nil {
+		return nil, err
+	}
+	return &revision, nil
+}
+
+// revision of page content, the first one is the original page
+type PageRevision struct {
+	Id      int64
+	PageId  int64     `xorm:"index"`
+	UserId  int64     `xorm:"index"`
+	Title   string    `xorm:"varchar(60)"`
+	Content string    `xorm:"text"`
+	Reason  string    `xorm:"varchar(255)"`
+	Created time.Time `xorm:"created"`
+}
+
+func (m *PageRevision) String() string {
+	return utils.ToStr(m.Id)
+}
+
+func (m *PageRevision) User() *User {
+	return getUser(m.UserId)
+}
+
+// AddPageRevision stores the edit of page, original is the page before edit
+// or nil for the new page. The original page is stored as the first
+// revision if it hasn't been.
+func AddPageRevision(original, page *Page, userId int64, reason string) error {
+	sess := orm.NewSession()
+	defer sess.Close()
+	if err := sess.Begin(); err != nil {
+		return err
+	}
+
+	if original != nil {
+		cnt, err := sess.Count(&PageRevision{PageId: page.Id})
+		if err != nil {
+			sess.Rollback()
+			return err
+		}
+		if cnt == 0 {
+			first := PageRevision{
+				PageId:  original.Id,
+				UserId:  original.UserId,
+				Title:   original.Title,
+				Content: original.Content,
+				Created: original.Created,
+			}
+			if _, err := sess.NoAutoTime().Insert(&first); err != nil {
+				sess.Rollback()
+				return err
+			}
+		}
+	}
+
+	revision := PageRevision{
+		PageId:  page.Id,
+		UserId:  userId,
+		Title:   page.Title,
+		Content: page.Content,
+		Reason:  reason,
+	}
+	if _, err := sess.Insert(&revision); err != nil {
+		sess.Rollback()
+		return err
+	}
+	return sess.Commit()
+}
+
+func FindPageRevisions(pageId int64) ([]PageRevision, error) {
+	var revisions = make([]PageRevision, 0)
+	err := orm.Where("page_id = ?", pageId).Asc("id").Find(&revisions)
+	return revisions, err
+}
+
+// FindPageRevisionsInfo returns the revisions of page without content.
+func FindPageRevisionsInfo(pageId int64) ([]PageRevision, error) {
+	var revisions = make([]PageRevision, 0)
+	err := orm.Where("page_id = ?", pageId).Omit("content").Asc("id").Find(&revisions)
+	return revisions, err
+}
+
+// PageRevisionNumber returns the number of revision in the revisions of its
+// page, the original page is the first one.
+func PageRevisionNumber(revision *PageRevision) (int64, error) {
+	return orm.Where("page_id = ? AND id <= ?", revision.PageId, revision.Id).Count(new(PageRevision))
+}
+
+func CountPageRevisions(pageId int64) (int64, error) {
+	return orm.Count(&PageRevision{PageId: pageId})
+}
+
+// FindPageRevisionsDesc returns a page of revisions from the newest one,
+// with one more revision which the last one of the page is changed from.
+func FindPageRevisionsDesc(pageId int64, limit, start int) ([]PageRevision, error) {
+	var revisions = make([]PageRevision, 0)
+	err := orm.Where("page_id = ?", pageId).Desc("id").Limit(limit+1, start).Find(&revisions)
+	return revisions, err
+}
+
+func GetPageRevision(pageId, id int64) (*PageRevision, error) {
+	var revision = PageRevision{Id: id, PageId: pageId}
+	if err := GetByExample(&revision); err != nil {
+		return nil, err
+	}
+	return &revision, nil
+}
@@ -0,0 +1,399 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/go-xorm/xorm"
+	"github.com/missdeer/wego/modules/utils"
+	"github.com/missdeer/wego/setting"
+)
+
+var ErrSameTag = errors.New("can not merge tag into itself")
+
+// letters, digits and the signs used by names like c++, c# and .net
+var tagNameRegexp = regexp.MustCompile(`^[\p{L}\p{N}][\p{L}\p{N}\-_.+#]*$`)
+
+// free-form tag of posts, a tag with SynonymId is a synonym of that tag
+type Tag struct {
+	Id        int64
+	Name      string    `xorm:"varchar(30) unique"`
+	Slug      string    `xorm:"varchar(100) unique"`
+	Intro     string    `xorm:"text"`
+	Posts     int       `xorm:"index"`
+	SynonymId int64     `xorm:"index"`
+	Created   time.Time `xorm:"created"`
+	Updated   time.Time `xorm:"updated"`
+}
+
+func (m *Tag) String() string {
+	return utils.ToStr(m.Id)
+}
+
+func (m *Tag) Link() string {
+	return fmt.Sprintf("%stag/%s", setting.AppUrl, m.Slug)
+}
+
+func (m *Tag) IsSynonym() bool {
+	return m.SynonymId > 0
+}
+
+// the tag which this synonym stands for
+func (m *Tag) Synonym() *Tag {
+	if m.SynonymId == 0 {
+		return nil
+	}
+	tag, err := GetTagById(m.SynonymId)
+	if err != nil {
+		return nil
+	}
+	return tag
+}
+
+// tags of post
+type PostTag struct {
+	Id      int64
+	PostId  int64     `xorm:"index"`
+	TagId   int64     `xorm:"index"`
+	Created time.Time `xorm:"created"`
+}
+
+// NormalizeTagName lowers the name and joins words with dash,
+// an empty string is returned for invalid names.
+func NormalizeTagName(name string) string {
+	name = strings.ToLower(strings.Join(strings.Fields(name), "-"))
+	if len([]rune(name)) > 30 || !tagNameRegexp.MatchString(name) {
+		return ""
+	}
+	return name
+}
+
+// signs of tag name which are not safe in url
+var tagSlugReplacer = strings.NewReplacer("+", "-plus", "#", "-sharp")
+
+func tagSlug(name string) string {
+	return tagSlugReplacer.Replace(name)
+}
+
+// ParseTagNames splits the comma separated tag names,
+// invalid and duplicated names are dropped.
+func ParseTagNames(value string) []string {
+	var names []string
+	seen := make(map[string]bool)
+	for _, name := range strings.Split(value, ",") {
+		name = NormalizeTagName(name)
+		if len(name) == 0 || seen[name] {
+			continue
+		}
+		seen[name] = true
+		names = append(names, name)
+	}
+	return names
+}
+
+func GetTagById(id int64) (*Tag, error) {
+	var tag Tag
+	has, err := orm.Id(id).Get(&tag)
+	if err != nil {
+		return nil, err
+	}
+	if !has {
+		return nil, ErrNotExist
+	}
+	return &tag, nil
+}
+
+func GetTagByName(name string) (*Tag, error) {
+	var tag Tag
+	has, err := orm.Where("name = ?", name).Get(&tag)
+	if err != nil {
+		return nil, err
+	}
+	if !has {
+		return nil, ErrNotExist
+	}
+	return &tag, nil
+}
+
+func GetTagBySlug(slug string) (*Tag, error) {
+	var tag Tag
+	has, err := orm.Where("slug = ?", slug).Get(&tag)
+	if err != nil {
+		return nil, err
+	}
+	if !has {
+		return nil, ErrNotExist
+	}
+	return &tag, nil
+}
+
+// SearchTags returns the names of tags starting with prefix for autocomplete,
+// synonyms are replaced by the tags they stand for.
+func SearchTags(prefix string, limit int) ([]string, error) {
+	prefix = NormalizeTagName(prefix)
+	if len(prefix) == 0 {
+		return nil, nil
+	}
+
+	var tags = make([]Tag, 0)
+	err := orm.Where("name LIKE ?", prefix+"%").Desc("posts").Limit(limit).Find(&tags)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(tags))
+	seen := make(map[string]bool)
+	for _, tag := range tags {
+		if synonym := tag.Synonym(); synonym != nil {
+			tag = *synonym
+		}
+		if !seen[tag.Name] {
+			seen[tag.Name] = true
+			names = append(names, tag.Name)
+		}
+	}
+	return names, nil
+}
+
+func FindTagsByPostId(postId int64) ([]Tag, error) {
+	var tags = make([]Tag, 0)
+	err := orm.Where("id IN (SELECT tag_id FROM post_tag WHERE post_id = ?)", postId).Asc("name").Find(&tags)
+	return tags, err
+}
+
+func (p *Post) Tags() []Tag {
+	tags, _ := FindTagsByPostId(p.Id)
+	return tags
+}
+
+// comma separated tag names of post
+func (p *Post) TagNames() string {
+	tags := p.Tags()
+	names := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		names = append(names, tag.Name)
+	}
+	return strings.Join(names, ",")
+}
+
+// slugs are unique, the slugs of names like c+ and c-plus are told apart
+// by a number suffix
+func uniqueTagSlug(sess *xorm.Session, name string) (string, error) {
+	base := tagSlug(name)
+	slug := base
+	for i := 2; ; i++ {
+		has, err := sess.Where("slug = ?", slug).Get(new(Tag))
+		if err != nil {
+			return "", err
+		}
+		if !has {
+			return slug, nil
+		}
+		slug = fmt.Sprintf("%s-%d", base, i)
+	}
+}
+
+// resolve the names to tags, synonyms are resolved to the tags they stand for
+// and unknown names are created
+func resolveTags(sess *xorm.Session, names []string) ([]*Tag, error) {
+	tags := make([]*Tag, 0, len(names))
+	seen := make(map[int64]bool)
+	for _, name := range names {
+		tag := &Tag{}
+		has, err := sess.Where("name = ?", name).Get(tag)
+		if err != nil {
+			return nil, err
+		}
+		if !has {
+			tag = &Tag{Name: name}
+			if tag.Slug, err = uniqueTagSlug(sess, name); err != nil {
+				return nil, err
+			}
+			if _, err = sess.Insert(tag); err != nil {
+				return nil, err
+			}
+		}
+
+		if tag.SynonymId > 0 {
+			// read in the transaction, the global orm may wait for its write
+			synonym := &Tag{}
+			has, err := sess.Id(tag.SynonymId).Get(synonym)
+			if err != nil {
+				return nil, err
+			}
+			if has {
+				tag = synonym
+			}
+		}
+		if !seen[tag.Id] {
+			seen[tag.Id] = true
+			tags = append(tags, tag)
+		}
+	}
+	return tags, nil
+}
+
+// recount posts of the tags
+func recountTags(sess *xorm.Session, tagIds []int64) error {
+	for _, tagId := range tagIds {
+		cnt, err := sess.Count(&PostTag{TagId: tagId})
+		if err != nil {
+			return err
+		}
+		if _, err = sess.Id(tagId).Cols("posts").Update(&Tag{Posts: int(cnt)}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SetPostTags replaces the tags of post by the names.
+func SetPostTags(post *Post, names []string) error {
+	sess := orm.NewSession()
+	defer sess.Close()
+	if err := sess.Begin(); err != nil {
+		return err
+	}
+	if err := setPostTags(sess, post, names); err != nil {
+		sess.Rollback()
+		return err
+	}
+	return sess.Commit()
+}
+
+// InsertPostWithTags saves the new post and its tags in one transaction,
+// no post is left without tags when the tags fail.
+func InsertPostWithTags(post *Post, names []string) error {
+	sess := orm.NewSession()
+	defer sess.Close()
+	if err := sess.Begin(); err != nil {
+		return err
+	}
+	if _, err := sess.Insert(post); err != nil {
+		sess.Rollback()
+		return err
+	}
+	if err := setPostTags(sess, post, names); err != nil {
+		sess.Rollback()
+		return err
+	}
+	return sess.Commit()
+}
+
+func setPostTags(sess *xorm.Session, post *Post, names []string) error {
+	tags, err := resolveTags(sess, names)
+	if err != nil {
+		return err
+	}
+
+	var olds = make([]PostTag, 0)
+	if err := sess.Find(&olds, &PostTag{PostId: post.Id}); err != nil {
+		return err
+	}
+
+	keep := make(map[int64]bool, len(tags))
+	for _, tag := range tags {
+		keep[tag.Id] = true
+	}
+
+	var changed []int64
+	for _, old := range olds {
+		if keep[old.TagId] {
+			delete(keep, old.TagId)
+			continue
+		}
+		if _, err := sess.Id(old.Id).Delete(new(PostTag)); err != nil {
+			return err
+		}
+		changed = append(changed, old.TagId)
+	}
+
+	for _, tag := range tags {
+		if !keep[tag.Id] {
+			continue
+		}
+		if _, err := sess.Insert(&PostTag{PostId: post.Id, TagId: tag.Id}); err != nil {
+			return err
+		}
+		changed = append(changed, tag.Id)
+	}
+	return recountTags(sess, changed)
+}
+
+// MergeTag moves the posts of source to target, source becomes a synonym
+// of target, and the synonyms of source are moved to target as well.
+func MergeTag(source, target *Tag) error {
+	if source.Id == target.Id {
+		return ErrSameTag
+	}
+	if target.SynonymId > 0 {
+		synonym := target.Synonym()
+		if synonym == nil {
+			return ErrNotExist
+		}
+		return MergeTag(source, synonym)
+	}
+
+	sess := orm.NewSession()
+	defer sess.Close()
+	if err := sess.Begin(); err != nil {
+		return err
+	}
+
+	// posts tagged with both tags keep the target only
+	if _, err := sess.Exec("DELETE FROM post_tag WHERE tag_id = ? AND post_id IN "+
+		"(SELECT post_id FROM (SELECT post_id FROM post_tag WHERE tag_id = ?) AS t)", source.Id, target.Id); err != nil {
+		sess.Rollback()
+		return err
+	}
+	if _, err := sess.Exec("UPDATE post_tag SET tag_id = ? WHERE tag_id = ?", target.Id, source.Id); err != nil {
+		sess.Rollback()
+		return err
+	}
+	if _, err := sess.Exec("UPDATE tag SET synonym_id = ? WHERE synonym_id = ? OR id = ?", target.Id, source.Id, source.Id); err != nil {
+		sess.Rollback()
+		return err
+	}
+	if err := recountTags(sess, []int64{source.Id, target.Id}); err != nil {
+		sess.Rollback()
+		return err
+	}
+	if err := sess.Commit(); err != nil {
+		return err
+	}
+
+	source.SynonymId = target.Id
+	return nil
+}
+
+// DeleteTag deletes the tag and untags its posts, the synonyms of tag are deleted too.
+func DeleteTag(tag *Tag) error {
+	sess := orm.NewSession()
+	defer sess.Close()
+	if err := sess.Begin(); err != nil {
+		return err
+	}
+
+	if _, err := sess.Where("tag_id = ?", tag.Id).Delete(new(PostTag)); err != nil {
+		sess.Rollback()
+		return err
+	}
+	if _, err := sess.Where("id = ? OR synonym_id = ?", tag.Id, tag.Id).Delete(new(Tag)); err != nil {
+		sess.Rollback()
+		return err
+	}
+	return sess.Commit()
+}
+
+func CountTags() (int64, error) {
+	return orm.Where("synonym_id = ?", 0).Count(new(Tag))
+}
+
+func FindTags(limit, start int) ([]Tag, error) {
+	var tags = make([]Tag, 0)
+	err := orm.Where("synonym_id = ?", 0).Desc("posts").Limit(limit, start).Find(&tags)
+	return tags, err
+}
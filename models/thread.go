@@ -0,0 +1,342 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/go-xorm/xorm"
+)
+
+var (
+	ErrSamePost     = errors.New("can not merge post into itself")
+	ErrNoComments   = errors.New("no comments selected")
+	ErrRedirectPost = errors.New("post is a redirect")
+)
+
+// location of comment in thread
+type commentFloor struct {
+	postId int64
+	floor  int
+}
+
+type commentsByCreated []*Comment
+
+func (c commentsByCreated) Len() int      { return len(c) }
+func (c commentsByCreated) Swap(i, j int) { c[i], c[j] = c[j], c[i] }
+func (c commentsByCreated) Less(i, j int) bool {
+	if c[i].Created.Equal(c[j].Created) {
+		return c[i].Id < c[j].Id
+	}
+	return c[i].Created.Before(c[j].Created)
+}
+
+// rethread moves comments to other posts by moves(comment id => post id),
+// renumbers the floors of posts, then fixes the notifications and counters.
+func rethread(sess *xorm.Session, postIds []int64, moves map[int64]int64) error {
+	var comments = make([]*Comment, 0)
+	if err := sess.In("post_id", postIds).Find(&comments); err != nil {
+		return err
+	}
+
+	olds := make(map[commentFloor]*Comment, len(comments))
+	changed := make(map[int64]bool)
+	threads := make(map[int64][]*Comment)
+	for _, comment := range comments {
+		olds[commentFloor{comment.PostId, comment.Floor}] = comment
+		if postId, ok := moves[comment.Id]; ok && postId != comment.PostId {
+			comment.PostId = postId
+			changed[comment.Id] = true
+		}
+		threads[comment.PostId] = append(threads[comment.PostId], comment)
+	}
+
+	for _, thread := range threads {
+		sort.Sort(commentsByCreated(thread))
+		for i, comment := range thread {
+			if comment.Floor != i+1 {
+				comment.Floor = i + 1
+				changed[comment.Id] = true
+			}
+		}
+	}
+
+	for _, comment := range comments {
+		if !changed[comment.Id] {
+			continue
+		}
+		if _, err := sess.Id(comment.Id).Cols("post_id", "floor").Update(comment); err != nil {
+			return err
+		}
+	}
+
+	// notifications follow the comments they point to
+	var notifications = make([]*Notification, 0)
+	if err := sess.In("target_id", postIds).Find(&notifications); err != nil {
+		return err
+	}
+	for _, notification := range notifications {
+		comment, ok := olds[commentFloor{notification.TargetId, notification.Floor}]
+		if !ok || notification.Floor == 0 {
+			continue
+		}
+		if comment.PostId == notification.TargetId && comment.Floor == notification.Floor {
+			continue
+		}
+		notification.TargetId = comment.PostId
+		notification.Uri = fmt.Sprintf("post/%d", comment.PostId)
+		notification.Floor = comment.Floor
+		if _, err := sess.Id(notification.Id).Cols("target_id", "uri", "floor").Update(notification); err != nil {
+			return err
+		}
+	}
+
+	for _, postId := range postIds {
+		if err := recountPost(sess, postId, threads[postId]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// recount replys and last reply of post from the sorted comments
+func recountPost(sess *xorm.Session, postId int64, comments []*Comment) error {
+	var post Post
+	if has, err := sess.Id(postId).Get(&post); err != nil {
+		return err
+	} else if !has {
+		return ErrNotExist
+	}
+
+	post.Replys = len(comments)
+	post.Floors = len(comments)
+	if len(comments) > 0 {
+		last := comments[len(comments)-1]
+		post.LastReplyId = last.UserId
+		post.LastReplied = last.Created
+	} else {
+		post.LastReplyId = post.UserId
+		post.LastReplied = post.Created
+	}
+	_, err := sess.Id(postId).NoAutoTime().Cols("replys", "floors", "last_reply_id", "last_replied").Update(&post)
+	return err
+}
+
+// RepairFloors renumbers the comments of every post from 1 in the order they
+// were created, resets the floor counters and fixes the floors of notifications.
+func RepairFloors() error {
+	var lastId int64
+	for {
+		var posts = make([]Post, 0)
+		if err := orm.Where("id > ?", lastId).Asc("id").Cols("id").Limit(100).Find(&posts); err != nil {
+			return err
+		}
+		if len(posts) == 0 {
+			return nil
+		}
+
+		postIds := make([]int64, 0, len(posts))
+		for _, post := range posts {
+			postIds = append(postIds, post.Id)
+		}
+		lastId = postIds[len(postIds)-1]
+
+		sess := orm.NewSession()
+		if err := sess.Begin(); err != nil {
+			sess.Close()
+			return err
+		}
+		if err := rethread(sess, postIds, nil); err != nil {
+			sess.Rollback()
+			sess.Close()
+			return err
+		}
+		err := sess.Commit()
+		sess.Close()
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// MovePost moves post to topic, a locked redirect post is left
+// in the original topic if leaveNote is set.
+func MovePost(post *Post, topic *Topic, leaveNote bool) error {
+	if post.RedirectId > 0 {
+		return ErrRedirectPost
+	}
+	if post.TopicId == topic.Id {
+		return nil
+	}
+
+	sess := orm.NewSession()
+	defer sess.Close()
+	if err := sess.Begin(); err != nil {
+		return err
+	}
+
+	if leaveNote {
+		note := Post{
+			UserId:       post.UserId,
+			Title:        post.Title,
+			TopicId:      post.TopicId,
+			CategoryId:   post.CategoryId,
+			Lang:         post.Lang,
+			LastReplyId:  post.LastReplyId,
+			LastAuthorId: post.LastAuthorId,
+			IsLock:       true,
+			RedirectId:   post.Id,
+			Created:      post.Created,
+			Updated:      time.Now(),
+			LastReplied:  post.LastReplied,
+		}
+		if _, err := sess.NoAutoTime().Insert(&note); err != nil {
+			sess.Rollback()
+			return err
+		}
+	}
+
+	post.TopicId = topic.Id
+	post.CategoryId = topic.CategoryId
+	if _, err := sess.Id(post.Id).NoAutoTime().Cols("topic_id", "category_id").Update(post); err != nil {
+		sess.Rollback()
+		return err
+	}
+	return sess.Commit()
+}
+
+// SplitPost splits the comments off post into a new post of topic,
+// the earliest comment becomes the content of new post.
+func SplitPost(post *Post, commentIds []int64, title string, topic *Topic) (*Post, error) {
+	if len(commentIds) == 0 {
+		return nil, ErrNoComments
+	}
+
+	sess := orm.NewSession()
+	defer sess.Close()
+	if err := sess.Begin(); err != nil {
+		return nil, err
+	}
+
+	var comments = make([]*Comment, 0)
+	if err := sess.In("id", commentIds).And("post_id = ?", post.Id).Find(&comments); err != nil {
+		sess.Rollback()
+		return nil, err
+	}
+	if len(comments) == 0 {
+		sess.Rollback()
+		return nil, ErrNoComments
+	}
+	sort.Sort(commentsByCreated(comments))
+
+	first := comments[0]
+	newPost := Post{
+		UserId:       first.UserId,
+		Title:        title,
+		Content:      first.Message,
+		ContentCache: first.MessageCache,
+		TopicId:      topic.Id,
+		CategoryId:   topic.CategoryId,
+		Lang:         post.Lang,
+		LastReplyId:  first.UserId,
+		LastAuthorId: first.UserId,
+		Created:      first.Created,
+		Updated:      first.Created,
+		LastReplied:  first.Created,
+	}
+	if _, err := sess.NoAutoTime().Insert(&newPost); err != nil {
+		sess.Rollback()
+		return nil, err
+	}
+
+	// the notifications of first comment point to the new post itself
+	if _, err := sess.Where("target_id = ? AND floor = ?", post.Id, first.Floor).Cols("target_id", "uri", "floor").
+		Update(&Notification{TargetId: newPost.Id, Uri: fmt.Sprintf("post/%d", newPost.Id)}); err != nil {
+		sess.Rollback()
+		return nil, err
+	}
+	if _, err := sess.Id(first.Id).Delete(new(Comment)); err != nil {
+		sess.Rollback()
+		return nil, err
+	}
+
+	moves := make(map[int64]int64, len(comments))
+	for _, comment := range comments[1:] {
+		moves[comment.Id] = newPost.Id
+	}
+	if err := rethread(sess, []int64{post.Id, newPost.Id}, moves); err != nil {
+		sess.Rollback()
+		return nil, err
+	}
+
+	if err := sess.Commit(); err != nil {
+		return nil, err
+	}
+	return &newPost, nil
+}
+
+// MergePost merges source post into target, the content of source becomes
+// a comment of target and source redirects to target.
+func MergePost(source, target *Post) error {
+	if source.Id == target.Id {
+		return ErrSamePost
+	}
+	if source.RedirectId > 0 || target.RedirectId > 0 {
+		return ErrRedirectPost
+	}
+
+	sess := orm.NewSession()
+	defer sess.Close()
+	if err := sess.Begin(); err != nil {
+		return err
+	}
+
+	body := Comment{
+		UserId:       source.UserId,
+		PostId:       target.Id,
+		Message:      source.Content,
+		MessageCache: source.ContentCache,
+		Created:      source.Created,
+	}
+	if _, err := sess.NoAutoTime().Insert(&body); err != nil {
+		sess.Rollback()
+		return err
+	}
+
+	var comments = make([]*Comment, 0)
+	if err := sess.Where("post_id = ?", source.Id).Find(&comments); err != nil {
+		sess.Rollback()
+		return err
+	}
+	moves := make(map[int64]int64, len(comments))
+	for _, comment := range comments {
+		moves[comment.Id] = target.Id
+	}
+	if err := rethread(sess, []int64{source.Id, target.Id}, moves); err != nil {
+		sess.Rollback()
+		return err
+	}
+
+	// the rest notifications of source point to the body comment
+	var bodyFloor int
+	if has, err := sess.Id(body.Id).Get(&body); err != nil {
+		sess.Rollback()
+		return err
+	} else if has {
+		bodyFloor = body.Floor
+	}
+	if _, err := sess.Where("target_id = ?", source.Id).Cols("target_id", "uri", "floor").
+		Update(&Notification{TargetId: target.Id, Uri: fmt.Sprintf("post/%d", target.Id), Floor: bodyFloor}); err != nil {
+		sess.Rollback()
+		return err
+	}
+
+	source.RedirectId = target.Id
+	source.IsHide = true
+	if _, err := sess.Id(source.Id).NoAutoTime().Cols("redirect_id", "is_hide").Update(source); err != nil {
+		sess.Rollback()
+		return err
+	}
+	return sess.Commit()
+}
@@ -2,6 +2,7 @@ package models
 
 import (
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/missdeer/wego/modules/utils"
@@ -36,6 +37,7 @@ type User struct {
 	Following   int
 	FavPosts    int
 	FavTopics   int
+	Reputation  int       `xorm:"index"`
 	IsAdmin     bool      `xorm:"index"`
 	IsActive    bool      `xorm:"index"`
 	IsForbid    bool      `xorm:"index"`
@@ -43,12 +45,24 @@ type User struct {
 	Rands       string    `xorm:"varchar(10)"`
 	Created     time.Time `xorm:"created"`
 	Updated     time.Time `xorm:"updated"`
+
+	// comma separated lang names of the posts to list, empty for all
+	ContentLangs string `xorm:"varchar(100)"`
 }
 
 func (m *User) String() string {
 	return utils.ToStr(m.Id)
 }
 
+// ContentLangList returns the names of the languages the user reads posts in,
+// empty means no choice was made.
+func (m *User) ContentLangList() []string {
+	if len(m.ContentLangs) == 0 {
+		return nil
+	}
+	return strings.Split(m.ContentLangs, ",")
+}
+
 func (m *User) Link() string {
 	return fmt.Sprintf("%suser/%s", setting.AppUrl, m.UserName)
 }
@@ -0,0 +1,151 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/missdeer/wego/setting"
+)
+
+var ErrSelfVote = errors.New("can not vote for yourself")
+
+// up or down vote of user on a post or comment, one for each target
+type Vote struct {
+	Id           int64
+	UserId       int64 `xorm:"unique(vote)"`
+	TargetType   int   `xorm:"unique(vote)"`
+	TargetId     int64 `xorm:"unique(vote)"`
+	TargetUserId int64 `xorm:"index"`
+	Value        int
+	Created      time.Time `xorm:"created"`
+	Updated      time.Time `xorm:"updated"`
+}
+
+// author of the vote target
+func voteTargetUser(targetType int, targetId int64) (int64, error) {
+	switch targetType {
+	case setting.VOTE_TARGET_POST:
+		post, err := GetPostById(targetId)
+		if err != nil {
+			return 0, err
+		}
+		return post.UserId, nil
+	case setting.VOTE_TARGET_COMMENT:
+		comment, err := GetCommentById(targetId)
+		if err != nil {
+			return 0, err
+		}
+		if comment.IsDelete {
+			return 0, ErrNotExist
+		}
+		return comment.UserId, nil
+	}
+	return 0, ErrNotExist
+}
+
+// SetVote sets the vote of user on the target, value is 1 for up, -1 for down
+// and 0 to cancel. The score of target and the reputation of its author are
+// recomputed from the votes, and the new score is returned.
+func SetVote(userId int64, targetType int, targetId int64, value int) (int, error) {
+	if value > 1 || value < -1 {
+		return 0, errors.New("invalid vote value")
+	}
+
+	targetUserId, err := voteTargetUser(targetType, targetId)
+	if err != nil {
+		return 0, err
+	}
+	if targetUserId == userId {
+		return 0, ErrSelfVote
+	}
+
+	// a concurrent first vote of the same user fails on the unique index,
+	// then the vote is saved again as an update
+	for retry := 0; ; retry++ {
+		score, err := setVote(userId, targetUserId, targetType, targetId, value)
+		if err == nil || retry > 0 {
+			return score, err
+		}
+	}
+}
+
+func setVote(userId, targetUserId int64, targetType int, targetId int64, value int) (int, error) {
+	sess := orm.NewSession()
+	defer sess.Close()
+	if err := sess.Begin(); err != nil {
+		return 0, err
+	}
+
+	var vote Vote
+	has, err := sess.Where("user_id = ? AND target_type = ? AND target_id = ?", userId, targetType, targetId).Get(&vote)
+	if err != nil {
+		sess.Rollback()
+		return 0, err
+	}
+
+	switch {
+	case value == 0 && has:
+		_, err = sess.Id(vote.Id).Delete(new(Vote))
+	case value != 0 && has:
+		vote.Value = value
+		_, err = sess.Id(vote.Id).Cols("value").Update(&vote)
+	case value != 0:
+		vote = Vote{UserId: userId, TargetType: targetType, TargetId: targetId, TargetUserId: targetUserId, Value: value}
+		_, err = sess.Insert(&vote)
+	}
+	if err != nil {
+		sess.Rollback()
+		return 0, err
+	}
+
+	// recompute from the votes in single statements, so concurrent votes can't lose updates
+	table := "post"
+	if targetType == setting.VOTE_TARGET_COMMENT {
+		table = "comment"
+	}
+	sql := fmt.Sprintf("UPDATE %s SET score = (SELECT COALESCE(SUM(value), 0) FROM vote WHERE target_type = ? AND target_id = ?) WHERE id = ?", orm.Quote(table))
+	if _, err := sess.Exec(sql, targetType, targetId, targetId); err != nil {
+		sess.Rollback()
+		return 0, err
+	}
+	sql = fmt.Sprintf("UPDATE %s SET reputation = (SELECT COALESCE(SUM(value), 0) FROM vote WHERE target_user_id = ?) WHERE id = ?", orm.Quote("user"))
+	if _, err := sess.Exec(sql, targetUserId, targetUserId); err != nil {
+		sess.Rollback()
+		return 0, err
+	}
+
+	var score int
+	if targetType == setting.VOTE_TARGET_COMMENT {
+		var comment Comment
+		_, err = sess.Id(targetId).Cols("score").Get(&comment)
+		score = comment.Score
+	} else {
+		var post Post
+		_, err = sess.Id(targetId).Cols("score").Get(&post)
+		score = post.Score
+	}
+	if err != nil {
+		sess.Rollback()
+		return 0, err
+	}
+	return score, sess.Commit()
+}
+
+// votes of user on the targets, target id => value
+func FindUserVotes(userId int64, targetType int, targetIds []int64) map[int64]int {
+	votes := make(map[int64]int)
+	if userId == 0 || len(targetIds) == 0 {
+		return votes
+	}
+
+	var list = make([]Vote, 0)
+	err := orm.Where("user_id = ? AND target_type = ?", userId, targetType).In("target_id", targetIds).Find(&list)
+	if err != nil {
+		return votes
+	}
+	for _, vote := range list {
+		votes[vote.TargetId] = vote.Value
+	}
+	return votes
+}
@@ -86,10 +86,12 @@ func RegisterUser(user *models.User, username, email, password string, locale i1
 	user.NickName = user.UserName
 
 	//set default language
-	if locale.Lang == "en-US" {
-		user.Lang = setting.LangEnUS
-	} else {
-		user.Lang = setting.LangZhCN
+	user.Lang = setting.DefaultLang
+	for i, lang := range setting.Langs {
+		if lang == locale.Lang {
+			user.Lang = i
+			break
+		}
 	}
 
 	//set default avatar
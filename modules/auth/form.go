@@ -160,6 +160,8 @@ type ProfileForm struct {
 	Facebook    string      `valid:"MaxSize(30)"`
 	Lang        int         `form:"type(select);attr(rel,select2)" valid:""`
 	Locale      i18n.Locale `form:"-"`
+
+	ContentLangs []string `form:"type(select);attr(rel,select2);attr(multiple,multiple)" valid:""`
 }
 
 func (form *ProfileForm) LangSelectData() [][]string {
@@ -171,14 +173,30 @@ func (form *ProfileForm) LangSelectData() [][]string {
 	return data
 }
 
+func (form *ProfileForm) ContentLangsSelectData() [][]string {
+	langs := setting.Langs
+	data := make([][]string, 0, len(langs))
+	for _, lang := range langs {
+		data = append(data, []string{lang, lang})
+	}
+	return data
+}
+
 func (form *ProfileForm) Valid(v *validation.Validation) {
 	if len(i18n.GetLangByIndex(form.Lang)) == 0 {
 		v.SetError("Lang", "Can not be empty")
 	}
+	for _, lang := range form.ContentLangs {
+		if !i18n.IsExist(lang) {
+			v.SetError("ContentLangs", "auth.invalid_content_langs")
+			break
+		}
+	}
 }
 
 func (form *ProfileForm) SetFromUser(user *models.User) {
-	utils.SetFormValues(user, form)
+	utils.SetFormValues(user, form, "ContentLangs")
+	form.ContentLangs = user.ContentLangList()
 }
 
 func (form *ProfileForm) SaveUserProfile(user *models.User) error {
@@ -187,7 +205,14 @@ func (form *ProfileForm) SaveUserProfile(user *models.User) error {
 		form.GrEmail = utils.EncodeMd5(form.GrEmail)
 	}
 
-	changes := utils.FormChanges(user, form)
+	changes := utils.FormChanges(user, form, "ContentLangs")
+
+	// none selected stands for all languages
+	if langs := strings.Join(form.ContentLangs, ","); langs != user.ContentLangs {
+		user.ContentLangs = langs
+		changes = append(changes, "ContentLangs")
+	}
+
 	if len(changes) > 0 {
 		// if email changed then need re-active
 		if user.Email != form.Email {
@@ -195,7 +220,7 @@ func (form *ProfileForm) SaveUserProfile(user *models.User) error {
 			changes = append(changes, "IsActive")
 		}
 
-		utils.SetFormValues(form, user)
+		utils.SetFormValues(form, user, "ContentLangs")
 		return models.UpdateById(user.Id, user, changes...)
 	}
 	return nil
@@ -203,21 +228,24 @@ func (form *ProfileForm) SaveUserProfile(user *models.User) error {
 
 func (form *ProfileForm) Labels() map[string]string {
 	return map[string]string{
-		"Lang":        "auth.profile_lang",
-		"NickName":    "model.user_nickname",
-		"PublicEmail": "auth.profile_publicemail",
-		"GrEmail":     "auth.profile_gremail",
-		"Info":        "auth.profile_info",
-		"Company":     "model.user_company",
-		"Location":    "model.user_location",
-		"Google":      ".Google+",
+		"Lang":         "auth.profile_lang",
+		"ContentLangs": "auth.profile_content_langs",
+		"NickName":     "model.user_nickname",
+		"PublicEmail":  "auth.profile_publicemail",
+		"GrEmail":      "auth.profile_gremail",
+		"Info":         "auth.profile_info",
+		"Company":      "model.user_company",
+		"Location":     "model.user_location",
+		"Google":       ".Google+",
 	}
 }
 
 func (form *ProfileForm) Helps() map[string]string {
 	return map[string]string{
-		"GrEmail": "auth.profile_gremail_help",
-		"Info":    "auth.plz_enter_your_info",
+		"Lang":         "auth.profile_lang_help",
+		"GrEmail":      "auth.profile_gremail_help",
+		"Info":         "auth.plz_enter_your_info",
+		"ContentLangs": "auth.profile_content_langs_help",
 	}
 }
 
@@ -21,11 +21,22 @@ import (
 	"github.com/missdeer/wego/models"
 	"github.com/missdeer/wego/modules/mailer"
 	"github.com/missdeer/wego/modules/utils"
+	"github.com/missdeer/wego/setting"
 	"github.com/tango-contrib/renders"
 )
 
+// mails are written in the preferred language of the user
+func mailLocale(user *models.User) i18n.Locale {
+	lang := i18n.GetLangByIndex(user.Lang)
+	if len(lang) == 0 {
+		lang = i18n.GetLangByIndex(setting.DefaultLang)
+	}
+	return i18n.Locale{Lang: lang}
+}
+
 // Send user register mail with active code
-func SendRegisterMail(renders *renders.Renders, locale i18n.Locale, user *models.User) {
+func SendRegisterMail(renders *renders.Renders, user *models.User) {
+	locale := mailLocale(user)
 	code := CreateUserActiveCode(user, nil)
 
 	subject := locale.Tr("mail.register_success_subject")
@@ -43,7 +54,8 @@ func SendRegisterMail(renders *renders.Renders, locale i18n.Locale, user *models
 }
 
 // Send user reset password mail with verify code
-func SendResetPwdMail(locale i18n.Locale, user *models.User) {
+func SendResetPwdMail(user *models.User) {
+	locale := mailLocale(user)
 	code := CreateUserResetPwdCode(user, nil)
 
 	subject := locale.Tr("mail.reset_password_subject")
@@ -60,7 +72,8 @@ func SendResetPwdMail(locale i18n.Locale, user *models.User) {
 }
 
 // Send email verify active email.
-func SendActiveMail(locale i18n.Locale, user *models.User) {
+func SendActiveMail(user *models.User) {
+	locale := mailLocale(user)
 	code := CreateUserActiveCode(user, nil)
 
 	subject := locale.Tr("mail.verify_your_email_subject")
@@ -0,0 +1,73 @@
+package ipblock
+
+import (
+	"strings"
+	"time"
+
+	"github.com/go-xweb/xweb/validation"
+	"github.com/missdeer/wego/models"
+	"github.com/missdeer/wego/modules/utils"
+	"github.com/missdeer/wego/setting"
+)
+
+type IpBlockAdminForm struct {
+	Create        bool   `form:"-"`
+	Cidr          string `valid:"Required;MaxSize(50)"`
+	BlockRead     bool   ``
+	BlockRegister bool   ``
+	BlockWrite    bool   ``
+	Expired       string `valid:"MaxSize(30)"`
+	Note          string `valid:"MaxSize(255)"`
+}
+
+func (form *IpBlockAdminForm) Valid(v *validation.Validation) {
+	if _, err := utils.ParseIPNet(form.Cidr); err != nil {
+		v.SetError("Cidr", "admin.ipblock_wrong_cidr")
+	}
+
+	if len(form.Expired) > 0 {
+		if _, err := utils.DateParse(form.Expired, setting.DateTimeFormat); err != nil {
+			v.SetError("Expired", "admin.ipblock_wrong_expired")
+		}
+	}
+
+	if !form.BlockRead && !form.BlockRegister && !form.BlockWrite {
+		v.SetError("BlockRead", "admin.ipblock_need_action")
+	}
+}
+
+func (form *IpBlockAdminForm) Labels() map[string]string {
+	return map[string]string{
+		"Cidr":          "model.ipblock_cidr",
+		"BlockRead":     "model.ipblock_block_read",
+		"BlockRegister": "model.ipblock_block_register",
+		"BlockWrite":    "model.ipblock_block_write",
+		"Expired":       "model.ipblock_expired",
+		"Note":          "model.ipblock_note",
+	}
+}
+
+func (form *IpBlockAdminForm) Helps() map[string]string {
+	return map[string]string{
+		"Cidr":    "admin.ipblock_cidr_help",
+		"Expired": "admin.ipblock_expired_help",
+	}
+}
+
+func (form *IpBlockAdminForm) SetFromIpBlock(block *models.IpBlock) {
+	utils.SetFormValues(block, form)
+
+	if !block.Expired.IsZero() {
+		form.Expired = utils.Date(block.Expired, setting.DateTimeFormat)
+	}
+}
+
+func (form *IpBlockAdminForm) SetToIpBlock(block *models.IpBlock) {
+	utils.SetFormValues(form, block)
+
+	block.Cidr = strings.TrimSpace(form.Cidr)
+	block.Expired = time.Time{}
+	if len(form.Expired) > 0 {
+		block.Expired, _ = utils.DateParse(form.Expired, setting.DateTimeFormat)
+	}
+}
@@ -0,0 +1,141 @@
+package ipblock
+
+import (
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/lunny/log"
+	"github.com/missdeer/wego/models"
+	"github.com/missdeer/wego/modules/utils"
+)
+
+const (
+	ActionRead = iota
+	ActionRegister
+	ActionWrite
+)
+
+type entry struct {
+	id      int64
+	ipNet   *net.IPNet
+	actions [3]bool
+	expired time.Time
+}
+
+var (
+	lock    sync.RWMutex
+	entries []entry
+
+	hitsLock sync.Mutex
+	hits     = make(map[int64]int64)
+)
+
+// Init loads the block list, then flushes the hit counters
+// and reloads the list every minute.
+func Init() {
+	if err := Reload(); err != nil {
+		log.Error("ipblock Reload: ", err)
+	}
+
+	go func() {
+		for range time.Tick(time.Minute) {
+			FlushHits()
+			if err := Reload(); err != nil {
+				log.Error("ipblock Reload: ", err)
+			}
+		}
+	}()
+}
+
+// Reload reloads the block list from database,
+// call it after the list is changed.
+func Reload() error {
+	blocks, err := models.FindIpBlocks()
+	if err != nil {
+		return err
+	}
+
+	list := make([]entry, 0, len(blocks))
+	for _, block := range blocks {
+		if block.IsExpired() {
+			continue
+		}
+		ipNet, err := utils.ParseIPNet(block.Cidr)
+		if err != nil {
+			log.Error("ipblock wrong cidr:", block.Id, err)
+			continue
+		}
+		list = append(list, entry{
+			id:      block.Id,
+			ipNet:   ipNet,
+			actions: [3]bool{block.BlockRead, block.BlockRegister, block.BlockWrite},
+			expired: block.Expired,
+		})
+	}
+
+	lock.Lock()
+	entries = list
+	lock.Unlock()
+	return nil
+}
+
+// Match returns id of the entry which denies the action from ip,
+// returns 0 if the ip is not blocked.
+func Match(ip string, action int) int64 {
+	addr := net.ParseIP(ip)
+	if addr == nil || action < ActionRead || action > ActionWrite {
+		return 0
+	}
+
+	now := time.Now()
+
+	lock.RLock()
+	defer lock.RUnlock()
+
+	for _, e := range entries {
+		if !e.actions[action] {
+			continue
+		}
+		if !e.expired.IsZero() && e.expired.Before(now) {
+			continue
+		}
+		if e.ipNet.Contains(addr) {
+			hitsLock.Lock()
+			hits[e.id]++
+			hitsLock.Unlock()
+			return e.id
+		}
+	}
+	return 0
+}
+
+// FlushHits saves the hit counters collected by Match.
+func FlushHits() {
+	hitsLock.Lock()
+	pending := hits
+	hits = make(map[int64]int64)
+	hitsLock.Unlock()
+
+	for id, n := range pending {
+		if err := models.AddIpBlockHits(id, n); err != nil {
+			log.Error("ipblock FlushHits: ", err)
+		}
+	}
+}
+
+// RequestAction returns the action of request judged by path and method.
+func RequestAction(req *http.Request) int {
+	path := req.URL.Path
+	if path == "/register" || strings.HasPrefix(path, "/register/") {
+		return ActionRegister
+	}
+
+	switch req.Method {
+	case "GET", "HEAD", "OPTIONS":
+		return ActionRead
+	}
+	return ActionWrite
+}
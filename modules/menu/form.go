@@ -0,0 +1,97 @@
+package menu
+
+import (
+	"github.com/go-xweb/xweb/validation"
+	"github.com/missdeer/wego/models"
+	"github.com/missdeer/wego/modules/utils"
+	"github.com/missdeer/wego/setting"
+)
+
+type MenuAdminForm struct {
+	Create   bool   `form:"-"`
+	Id       int    `form:"-"`
+	Position int    `form:"type(select);attr(rel,select2)"`
+	Parent   int    `form:"type(select);attr(rel,select2)"`
+	Name     string `valid:"Required;MaxSize(60)"`
+	Url      string `valid:"Required;MaxSize(255)"`
+	Order    int    ``
+	IsBlank  bool   ``
+}
+
+func (form *MenuAdminForm) PositionSelectData() [][]string {
+	return [][]string{
+		{"model.menu_navbar", utils.ToStr(setting.MENU_NAVBAR)},
+		{"model.menu_footer", utils.ToStr(setting.MENU_FOOTER)},
+	}
+}
+
+// the dropdown items are under the menus of navbar
+func (form *MenuAdminForm) ParentSelectData() [][]string {
+	data := [][]string{
+		{"model.menu_no_parent", "0"},
+	}
+	menus, _ := models.FindTopMenus(setting.MENU_NAVBAR)
+	for _, menu := range menus {
+		if int(menu.Id) != form.Id {
+			data = append(data, []string{menu.Name, utils.ToStr(menu.Id)})
+		}
+	}
+	return data
+}
+
+func (form *MenuAdminForm) Valid(v *validation.Validation) {
+	if form.Parent == 0 {
+		// the dropdown items are left out of the footer, keep them under navbar
+		if form.Id > 0 && form.Position != setting.MENU_NAVBAR {
+			if cnt, _ := models.CountMenuChildren(int64(form.Id)); cnt > 0 {
+				v.SetError("Position", "model.menu_has_children")
+			}
+		}
+		return
+	}
+	var parent models.Menu
+	if err := models.GetById(int64(form.Parent), &parent); err != nil || parent.ParentId > 0 ||
+		parent.Position != setting.MENU_NAVBAR || form.Parent == form.Id {
+		v.SetError("Parent", "model.menu_invalid_parent")
+		return
+	}
+	if form.Position != setting.MENU_NAVBAR {
+		v.SetError("Position", "model.menu_invalid_parent")
+		return
+	}
+	// menus have one level of dropdown items only
+	if form.Id > 0 {
+		if cnt, _ := models.CountMenuChildren(int64(form.Id)); cnt > 0 {
+			v.SetError("Parent", "model.menu_has_children")
+		}
+	}
+}
+
+func (form *MenuAdminForm) Labels() map[string]string {
+	return map[string]string{
+		"Position": "model.menu_position",
+		"Parent":   "model.menu_parent",
+		"Name":     "model.menu_name",
+		"Url":      "model.menu_url",
+		"Order":    "model.menu_order",
+		"IsBlank":  "model.menu_is_blank",
+	}
+}
+
+func (form *MenuAdminForm) Helps() map[string]string {
+	return map[string]string{
+		"Name": "model.menu_name_help",
+		"Url":  "model.menu_url_help",
+	}
+}
+
+func (form *MenuAdminForm) SetFromMenu(menu *models.Menu) {
+	utils.SetFormValues(menu, form)
+	form.Id = int(menu.Id)
+	form.Parent = int(menu.ParentId)
+}
+
+func (form *MenuAdminForm) SetToMenu(menu *models.Menu) {
+	utils.SetFormValues(form, menu, "Id")
+	menu.ParentId = int64(form.Parent)
+}
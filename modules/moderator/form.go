@@ -0,0 +1,143 @@
+package moderator
+
+import (
+	"github.com/go-xweb/xweb/validation"
+	"github.com/missdeer/wego/models"
+	"github.com/missdeer/wego/modules/utils"
+)
+
+type ModeratorAdminForm struct {
+	Create      bool  `form:"-"`
+	User        int64 `form:"attr(rel,select2-admin-model);attr(data-model,User)" valid:"Required"`
+	Category    int64 `form:"type(select);attr(rel,select2)" valid:""`
+	Topic       int64 `form:"type(select);attr(rel,select2)" valid:""`
+	EditPost    bool  ``
+	HidePost    bool  ``
+	LockPost    bool  ``
+	PinPost     bool  ``
+	MovePost    bool  ``
+	BestPost    bool  ``
+	EditComment bool  ``
+	HideComment bool  ``
+}
+
+func (form *ModeratorAdminForm) CategorySelectData() [][]string {
+	var cats []models.Category
+	models.FindCategories(&cats)
+	data := make([][]string, 0, len(cats)+1)
+	data = append(data, []string{"model.moderator_scope_all", "0"})
+	for _, cat := range cats {
+		data = append(data, []string{cat.Name, utils.ToStr(cat.Id)})
+	}
+	return data
+}
+
+func (form *ModeratorAdminForm) TopicSelectData() [][]string {
+	var topics []models.Topic
+	models.FindTopics(&topics)
+	data := make([][]string, 0, len(topics)+1)
+	data = append(data, []string{"model.moderator_scope_all", "0"})
+	for _, topic := range topics {
+		data = append(data, []string{topic.Name, utils.ToStr(topic.Id)})
+	}
+	return data
+}
+
+func (form *ModeratorAdminForm) Valid(v *validation.Validation) {
+	if _, err := models.GetUserById(form.User); err != nil {
+		v.SetError("User", "admin.not_found_by_id")
+	}
+
+	if form.Category > 0 {
+		var category models.Category
+		if err := models.GetById(form.Category, &category); err != nil {
+			v.SetError("Category", "admin.not_found_by_id")
+		}
+	}
+
+	if form.Topic > 0 {
+		if topic, err := models.GetTopicById(form.Topic); err != nil {
+			v.SetError("Topic", "admin.not_found_by_id")
+		} else if form.Category > 0 && topic.CategoryId != form.Category {
+			v.SetError("Topic", "admin.moderator_topic_not_in_category")
+		}
+	}
+
+	if form.perms() == 0 {
+		v.SetError("EditPost", "admin.moderator_need_permission")
+	}
+}
+
+func (form *ModeratorAdminForm) Labels() map[string]string {
+	return map[string]string{
+		"User":        "model.moderator_user",
+		"Category":    "model.category",
+		"Topic":       "model.topic",
+		"EditPost":    "model.moderator_edit_post",
+		"HidePost":    "model.moderator_hide_post",
+		"LockPost":    "model.moderator_lock_post",
+		"PinPost":     "model.moderator_pin_post",
+		"MovePost":    "model.moderator_move_post",
+		"BestPost":    "model.moderator_best_post",
+		"EditComment": "model.moderator_edit_comment",
+		"HideComment": "model.moderator_hide_comment",
+	}
+}
+
+func (form *ModeratorAdminForm) Helps() map[string]string {
+	return map[string]string{
+		"Topic": "admin.moderator_scope_help",
+	}
+}
+
+func (form *ModeratorAdminForm) perms() models.Permission {
+	var perm models.Permission
+	flags := []struct {
+		set  bool
+		perm models.Permission
+	}{
+		{form.EditPost, models.PermEditPost},
+		{form.HidePost, models.PermHidePost},
+		{form.LockPost, models.PermLockPost},
+		{form.PinPost, models.PermPinPost},
+		{form.MovePost, models.PermMovePost},
+		{form.BestPost, models.PermBestPost},
+		{form.EditComment, models.PermEditComment},
+		{form.HideComment, models.PermHideComment},
+	}
+	for _, f := range flags {
+		if f.set {
+			perm |= f.perm
+		}
+	}
+	return perm
+}
+
+func (form *ModeratorAdminForm) SetFromModerator(moderator *models.Moderator) {
+	form.User = moderator.UserId
+	form.Category = moderator.CategoryId
+	form.Topic = moderator.TopicId
+
+	perm := moderator.Perms
+	form.EditPost = perm.CanEditPost()
+	form.HidePost = perm.CanHidePost()
+	form.LockPost = perm.CanLockPost()
+	form.PinPost = perm.CanPinPost()
+	form.MovePost = perm.CanMovePost()
+	form.BestPost = perm.CanBestPost()
+	form.EditComment = perm.CanEditComment()
+	form.HideComment = perm.CanHideComment()
+}
+
+func (form *ModeratorAdminForm) SetToModerator(moderator *models.Moderator) {
+	moderator.UserId = form.User
+	moderator.CategoryId = form.Category
+	moderator.TopicId = form.Topic
+	// topic scope always belongs to the category of topic
+	if form.Topic > 0 {
+		if topic, err := models.GetTopicById(form.Topic); err == nil {
+			moderator.CategoryId = topic.CategoryId
+		}
+	}
+	moderator.Perms = form.perms()
+}
@@ -15,6 +15,8 @@
 package page
 
 import (
+	"strings"
+
 	"github.com/go-xweb/xweb/validation"
 	"github.com/missdeer/wego/models"
 	"github.com/missdeer/wego/modules/utils"
@@ -22,32 +24,114 @@ import (
 
 type PageAdminForm struct {
 	Create     bool   `form:"-"`
+	Id         int    `form:"-"`
 	User       int    `form:"attr(rel,select2-admin-model);attr(data-model,User)" valid:"Required"`
 	LastAuthor int    `form:"attr(rel,select2-admin-model);attr(data-model,User)" valid:""`
 	Uri        string `valid:"Required;MaxSize(60);Match(/[0-9a-z-./]+/)"`
 	Title      string `valid:"Required;MaxSize(60)"`
 	Content    string `form:"type(textarea,markdown)" valid:"Required"`
 	IsPublish  bool   ``
+	IsWiki     bool   ``
+	Protection int    `form:"type(select);attr(rel,select2)"`
+	Parent     int    `form:"attr(rel,select2-admin-model);attr(data-model,Page)" valid:""`
+	Order      int    ``
+}
+
+func (form *PageAdminForm) ProtectionSelectData() [][]string {
+	return protectionSelectData()
 }
 
 func (form *PageAdminForm) Valid(v *validation.Validation) {
 	if models.IsExist(&models.User{Id: int64(form.User)}) {
 		v.SetError("User", "admin.not_found_by_id")
 	}
+	if form.Parent > 0 {
+		if !models.IsExist(&models.Page{Id: int64(form.Parent)}) {
+			v.SetError("Parent", "admin.not_found_by_id")
+		} else if form.Id > 0 && models.IsPageAncestor(int64(form.Id), int64(form.Parent)) {
+			// the page can't be under itself
+			v.SetError("Parent", "model.page_invalid_parent")
+		}
+	}
 }
 
 func (form *PageAdminForm) SetFromPage(page *models.Page) {
 	utils.SetFormValues(page, form)
 
+	form.Id = int(page.Id)
 	form.User = int(page.UserId)
 	form.LastAuthor = int(page.LastAuthorId)
+	form.Parent = int(page.ParentId)
 }
 
 func (form *PageAdminForm) SetToPage(page *models.Page) {
-	utils.SetFormValues(form, page)
+	utils.SetFormValues(form, page, "Id")
 
 	page.UserId = int64(form.User)
 	page.LastAuthorId = int64(form.LastAuthor)
+	page.ParentId = int64(form.Parent)
 
 	page.ContentCache = utils.RenderMarkdown(page.Content)
 }
+
+func protectionSelectData() [][]string {
+	return [][]string{
+		{"model.page_protect_none", utils.ToStr(models.PageProtectNone)},
+		{"model.page_protect_semi", utils.ToStr(models.PageProtectSemi)},
+		{"model.page_protect_full", utils.ToStr(models.PageProtectFull)},
+	}
+}
+
+// form of wiki page, the title is set when the page is created only
+type WikiForm struct {
+	Create  bool   `form:"-"`
+	Uri     string `form:"-"`
+	Title   string `form:"attr(autocomplete,off)" valid:"MaxSize(60)"`
+	Content string `form:"type(textarea)" valid:"Required;MinSize(10);MaxSize(30000)"`
+	Reason  string `form:"attr(autocomplete,off)" valid:"MaxSize(255)"`
+}
+
+func (form *WikiForm) Valid(v *validation.Validation) {
+	if form.Create && len(strings.TrimSpace(form.Title)) == 0 {
+		v.SetError("Title", "post.plz_enter_title")
+	} else if form.Create && models.WikiPageUri(form.Title) != form.Uri {
+		// the [[title]] links find the page by the uri of title
+		v.SetError("Title", "page.title_not_match_uri")
+	}
+}
+
+func (form *WikiForm) SetFromPage(page *models.Page) {
+	form.Title = page.Title
+	form.Content = page.Content
+}
+
+// CreatePage creates the wiki page at the uri of page.
+func (form *WikiForm) CreatePage(page *models.Page, user *models.User) error {
+	page.Title = strings.TrimSpace(form.Title)
+	page.Content = form.Content
+	page.ContentCache = utils.RenderMarkdown(form.Content)
+	return models.CreateWikiPage(page, user.Id, form.Reason)
+}
+
+// UpdatePage saves the content of page and keeps the edit as a revision.
+func (form *WikiForm) UpdatePage(page *models.Page, user *models.User) error {
+	if form.Content == page.Content {
+		return nil
+	}
+	original := *page
+	page.Content = form.Content
+	page.ContentCache = utils.RenderMarkdown(form.Content)
+	page.LastAuthorId = user.Id
+	if err := models.UpdateById(page.Id, page, "content", "content_cache", "last_author_id", "updated"); err != nil {
+		return err
+	}
+	return models.AddPageRevision(&original, page, user.Id, form.Reason)
+}
+
+func (form *WikiForm) Placeholders() map[string]string {
+	return map[string]string{
+		"Title":   "post.plz_enter_title",
+		"Content": "post.plz_enter_content",
+		"Reason":  "post.plz_enter_edit_reason",
+	}
+}
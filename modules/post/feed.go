@@ -0,0 +1,65 @@
+package post
+
+import (
+	"encoding/xml"
+	"time"
+
+	"github.com/missdeer/wego/models"
+)
+
+type rssFeed struct {
+	XMLName xml.Name   `xml:"rss"`
+	Version string     `xml:"version,attr"`
+	Channel rssChannel `xml:"channel"`
+}
+
+type rssChannel struct {
+	Title         string    `xml:"title"`
+	Link          string    `xml:"link"`
+	Description   string    `xml:"description"`
+	LastBuildDate string    `xml:"lastBuildDate"`
+	Items         []rssItem `xml:"item"`
+}
+
+type rssItem struct {
+	Title       string `xml:"title"`
+	Link        string `xml:"link"`
+	Guid        string `xml:"guid"`
+	Author      string `xml:"author,omitempty"`
+	PubDate     string `xml:"pubDate"`
+	Description string `xml:"description"`
+}
+
+// PostsFeed renders the posts as RSS 2.0 feed.
+func PostsFeed(title, link, description string, posts []models.Post) ([]byte, error) {
+	feed := rssFeed{
+		Version: "2.0",
+		Channel: rssChannel{
+			Title:         title,
+			Link:          link,
+			Description:   description,
+			LastBuildDate: time.Now().Format(time.RFC1123Z),
+			Items:         make([]rssItem, 0, len(posts)),
+		},
+	}
+	for i := range posts {
+		post := &posts[i]
+		item := rssItem{
+			Title:       post.Title,
+			Link:        post.Link(),
+			Guid:        post.Link(),
+			PubDate:     post.Created.Format(time.RFC1123Z),
+			Description: post.GetContentCache(),
+		}
+		if user := post.User(); user != nil {
+			item.Author = user.NickName
+		}
+		feed.Channel.Items = append(feed.Channel.Items, item)
+	}
+
+	data, err := xml.MarshalIndent(&feed, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), data...), nil
+}
@@ -15,6 +15,9 @@
 package post
 
 import (
+	"strings"
+	"time"
+
 	"github.com/Unknwon/i18n"
 	"github.com/go-xweb/xweb/validation"
 	"github.com/missdeer/wego/models"
@@ -23,10 +26,19 @@ import (
 )
 
 type PostForm struct {
-	Lang     int            `form:"type(select);attr(rel,select2)"`
-	Topic    int64          `form:"type(select);attr(rel,select2)" valid:"Required"`
-	Title    string         `form:"attr(autocomplete,off)" valid:"Required;MinSize(5);MaxSize(60)"`
-	Content  string         `form:"type(textarea)" valid:"Required;MinSize(10)"`
+	Lang    int    `form:"type(select);attr(rel,select2)"`
+	Topic   int64  `form:"type(select);attr(rel,select2)" valid:"Required"`
+	Title   string `form:"attr(autocomplete,off)" valid:"Required;MinSize(5);MaxSize(60)"`
+	Content string `form:"type(textarea)" valid:"Required;MinSize(10)"`
+	Tags    string `form:"attr(rel,post-tags);attr(autocomplete,off)" valid:"MaxSize(255)"`
+	Reason  string `form:"attr(autocomplete,off)" valid:"MaxSize(255)"`
+	// one option of poll each line, no options means no poll
+	PollOptions     string `form:"type(textarea)" valid:"MaxSize(2000)"`
+	PollMultiple    bool   ``
+	PollShowResults bool   ``
+	PollClosed      string `form:"attr(autocomplete,off)" valid:"MaxSize(30)"`
+	// empty publish time means the post is published at once
+	Publish  string         `form:"attr(autocomplete,off)" valid:"MaxSize(30)"`
 	Category int64          `form:"-"`
 	Topics   []models.Topic `form:"-"`
 	Locale   i18n.Locale    `form:"-"`
@@ -64,6 +76,76 @@ func (form *PostForm) Valid(v *validation.Validation) {
 	if len(i18n.GetLangByIndex(form.Lang)) == 0 {
 		v.SetError("Lang", "error")
 	}
+
+	for _, name := range strings.Split(form.Tags, ",") {
+		if len(strings.TrimSpace(name)) > 0 && len(models.NormalizeTagName(name)) == 0 {
+			v.SetError("Tags", "post.invalid_tag")
+			return
+		}
+	}
+	if len(models.ParseTagNames(form.Tags)) > setting.PostMaxTags {
+		v.SetError("Tags", "post.too_many_tags")
+	}
+
+	options := form.pollTitles()
+	for _, title := range options {
+		if len([]rune(title)) > 100 {
+			v.SetError("PollOptions", "post.poll_option_too_long")
+			return
+		}
+	}
+	if len(options) == 1 {
+		v.SetError("PollOptions", "post.poll_too_few_options")
+	} else if len(options) > setting.PollMaxOptions {
+		v.SetError("PollOptions", "post.poll_too_many_options")
+	}
+	if _, err := form.pollClosed(); err != nil {
+		v.SetError("PollClosed", "post.poll_invalid_closed")
+	}
+	if publish, err := form.publishAt(); err != nil || (!publish.IsZero() && !publish.After(time.Now())) {
+		v.SetError("Publish", "post.invalid_publish_at")
+	}
+}
+
+// distinct options of poll, one each line
+func (form *PostForm) pollTitles() []string {
+	var titles []string
+	seen := make(map[string]bool)
+	for _, title := range strings.Split(form.PollOptions, "\n") {
+		title = strings.TrimSpace(title)
+		if len(title) == 0 || seen[title] {
+			continue
+		}
+		seen[title] = true
+		titles = append(titles, title)
+	}
+	return titles
+}
+
+// empty close date means the poll never closes
+func (form *PostForm) pollClosed() (time.Time, error) {
+	if len(strings.TrimSpace(form.PollClosed)) == 0 {
+		return time.Time{}, nil
+	}
+	return utils.DateParse(strings.TrimSpace(form.PollClosed), setting.DateTimeFormat)
+}
+
+// empty publish time means the post is published at once
+func (form *PostForm) publishAt() (time.Time, error) {
+	if len(strings.TrimSpace(form.Publish)) == 0 {
+		return time.Time{}, nil
+	}
+	return utils.DateParse(strings.TrimSpace(form.Publish), setting.DateTimeFormat)
+}
+
+func (form *PostForm) savePoll(post *models.Post) error {
+	closed, _ := form.pollClosed()
+	poll := models.Poll{
+		Multiple:    form.PollMultiple,
+		ShowResults: form.PollShowResults,
+		Closed:      closed,
+	}
+	return models.SavePoll(post, &poll, form.pollTitles())
 }
 
 func (form *PostForm) SavePost(post *models.Post, user *models.User) error {
@@ -75,68 +157,184 @@ func (form *PostForm) SavePost(post *models.Post, user *models.User) error {
 	post.LastAuthorId = user.Id
 	post.CanEdit = true
 	post.ContentCache = utils.RenderMarkdown(form.Content)
+	if publish, _ := form.publishAt(); !publish.IsZero() {
+		post.IsScheduled = true
+		post.PublishAt = publish
+	}
 
-	// mentioned follow users
-	FilterMentions(user, post.ContentCache)
-
-	return post.Insert()
+	if err := models.InsertPostWithTags(post, models.ParseTagNames(form.Tags)); err != nil {
+		return err
+	}
+	if err := form.savePoll(post); err != nil {
+		return err
+	}
+	if !post.IsScheduled {
+		PostPublished(user, post)
+	}
+	return nil
 }
 
 func (form *PostForm) SetFromPost(post *models.Post) {
 	utils.SetFormValues(post, form)
 	form.Category = post.CategoryId
 	form.Topic = post.TopicId
+	form.Tags = post.TagNames()
+	if post.IsScheduled {
+		form.Publish = utils.Date(post.PublishAt, setting.DateTimeFormat)
+	}
+	if poll := post.Poll(); poll != nil {
+		titles := make([]string, 0)
+		for _, option := range poll.Options() {
+			titles = append(titles, option.Title)
+		}
+		form.PollOptions = strings.Join(titles, "\n")
+		form.PollMultiple = poll.Multiple
+		form.PollShowResults = poll.ShowResults
+		if !poll.Closed.IsZero() {
+			form.PollClosed = utils.Date(poll.Closed, setting.DateTimeFormat)
+		}
+	}
 }
 
 func (form *PostForm) UpdatePost(post *models.Post, user *models.User) error {
+	if tags := models.ParseTagNames(form.Tags); strings.Join(tags, ",") != post.TagNames() {
+		if err := models.SetPostTags(post, tags); err != nil {
+			return err
+		}
+	}
+	if err := form.savePoll(post); err != nil {
+		return err
+	}
+	if err := form.saveEdit(post, user); err != nil {
+		return err
+	}
+	// published after the edit is saved, the mentions are found in the new content
+	if post.IsScheduled {
+		return form.reschedule(post, user)
+	}
+	return nil
+}
+
+// saveEdit saves the changed fields of post and keeps the edit of content
+// as a revision.
+func (form *PostForm) saveEdit(post *models.Post, user *models.User) error {
 	changes := utils.FormChanges(post, form)
 	if len(changes) == 0 {
 		return nil
 	}
+	original := *post
 	utils.SetFormValues(form, post)
 	post.CategoryId = form.Category
 	post.TopicId = form.Topic
+	revised := false
 	for _, c := range changes {
-		if c == "Content" {
+		switch c {
+		case "Content":
 			post.ContentCache = utils.RenderMarkdown(form.Content)
 			changes = append(changes, "ContentCache")
+			revised = true
+		case "Title":
+			revised = true
 		}
 	}
 
 	// update last edit author
 	if post.LastAuthorId != user.Id {
 		post.LastAuthorId = user.Id
-		changes = append(changes, "LastAuthor")
+		changes = append(changes, "LastAuthorId")
 	}
 
+	// the edit time is set by hand, drafts of edit compare with it for conflicts
+	post.Updated = time.Now()
 	changes = append(changes, "Updated")
 
+	// keep every edit of content as a revision
+	if revised {
+		return models.UpdatePostRevision(&original, post, user.Id, form.Reason, models.Obj2Table(changes)...)
+	}
 	return models.UpdateById(post.Id, post, models.Obj2Table(changes)...)
 }
 
+// reschedule moves the publish time of scheduled post, the post is
+// published at once when the publish time is cleared
+func (form *PostForm) reschedule(post *models.Post, user *models.User) error {
+	publish, _ := form.publishAt()
+	if publish.IsZero() {
+		published, err := models.PublishPost(post)
+		if err != nil {
+			return err
+		}
+		if published {
+			PostPublished(user, post)
+		}
+		return nil
+	}
+	if publish.Equal(post.PublishAt) {
+		return nil
+	}
+	post.PublishAt = publish
+	return models.UpdateById(post.Id, post, "publish_at")
+}
+
 func (form *PostForm) Placeholders() map[string]string {
 	return map[string]string{
-		"Category": "model.category_choose_dot",
-		"Topic":    "model.topic_choose_dot",
-		"Title":    "post.plz_enter_title",
-		"Content":  "post.plz_enter_content",
+		"Category":    "model.category_choose_dot",
+		"Topic":       "model.topic_choose_dot",
+		"Title":       "post.plz_enter_title",
+		"Content":     "post.plz_enter_content",
+		"Tags":        "post.plz_enter_tags",
+		"Reason":      "post.plz_enter_edit_reason",
+		"PollOptions": "post.plz_enter_poll_options",
+		"PollClosed":  "post.plz_enter_poll_closed",
+		"Publish":     "post.plz_enter_publish_at",
+	}
+}
+
+func (form *PostForm) Labels() map[string]string {
+	return map[string]string{
+		"PollOptions":     "post.poll_options",
+		"PollMultiple":    "post.poll_multiple",
+		"PollShowResults": "post.poll_show_results",
+		"PollClosed":      "post.poll_closed",
+		"Publish":         "post.publish_at",
+	}
+}
+
+func (form *PostForm) Helps() map[string]string {
+	return map[string]string{
+		"PollOptions": "post.poll_options_help",
+		"Publish":     "post.publish_at_help",
 	}
 }
 
 type PostAdminForm struct {
-	PostForm   `form:"-"`
-	Create     bool   `form:"-"`
-	User       int64  `form:"attr(rel,select2-admin-model);attr(data-model,User)" valid:"Required"`
-	Title      string `valid:"Required;MaxSize(60)"`
-	Content    string `form:"type(textarea,markdown)" valid:"Required"`
-	Browsers   int    ``
-	Replys     int    ``
-	Favorites  int    ``
-	LastReply  int64  `form:"attr(rel,select2-admin-model);attr(data-model,User)" valid:""`
-	LastAuthor int64  `form:"attr(rel,select2-admin-model);attr(data-model,User)" valid:""`
-	Topic      int64  `form:"type(select);attr(rel,select2)" valid:"Required"`
-	Lang       int    `form:"type(select);attr(rel,select2)"`
-	IsBest     bool   ``
+	PostForm      `form:"-"`
+	Create        bool   `form:"-"`
+	User          int64  `form:"attr(rel,select2-admin-model);attr(data-model,User)" valid:"Required"`
+	Title         string `valid:"Required;MaxSize(60)"`
+	Content       string `form:"type(textarea,markdown)" valid:"Required"`
+	Browsers      int    ``
+	Replys        int    ``
+	Favorites     int    ``
+	LastReply     int64  `form:"attr(rel,select2-admin-model);attr(data-model,User)" valid:""`
+	LastAuthor    int64  `form:"attr(rel,select2-admin-model);attr(data-model,User)" valid:""`
+	Topic         int64  `form:"type(select);attr(rel,select2)" valid:"Required"`
+	Lang          int    `form:"type(select);attr(rel,select2)"`
+	IsBest        bool   ``
+	IsHide        bool   ``
+	IsLock        bool   ``
+	Sticky        int    `form:"type(select);attr(rel,select2)"`
+	StickyExpired string `valid:"MaxSize(30)"`
+}
+
+func (form *PostAdminForm) StickySelectData() [][]string {
+	data := [][]string{
+		[]string{"post.sticky_none", utils.ToStr(setting.STICKY_NONE)},
+		[]string{"post.sticky_topic", utils.ToStr(setting.STICKY_TOPIC)},
+		[]string{"post.sticky_category", utils.ToStr(setting.STICKY_CATEGORY)},
+		[]string{"post.sticky_global", utils.ToStr(setting.STICKY_GLOBAL)},
+	}
+	return data
 }
 
 func (form *PostAdminForm) Valid(v *validation.Validation) {
@@ -160,6 +358,16 @@ func (form *PostAdminForm) Valid(v *validation.Validation) {
 	if len(i18n.GetLangByIndex(form.Lang)) == 0 {
 		v.SetError("Lang", "Not Found")
 	}
+
+	if form.Sticky < setting.STICKY_NONE || form.Sticky > setting.STICKY_GLOBAL {
+		v.SetError("Sticky", "Not Found")
+	}
+
+	if len(form.StickyExpired) > 0 {
+		if _, err = utils.DateParse(form.StickyExpired, setting.DateTimeFormat); err != nil {
+			v.SetError("StickyExpired", "post.sticky_wrong_expired")
+		}
+	}
 }
 
 func (form *PostAdminForm) SetFromPost(post *models.Post) {
@@ -169,6 +377,9 @@ func (form *PostAdminForm) SetFromPost(post *models.Post) {
 	form.LastReply = post.LastReplyId
 	form.LastAuthor = post.LastAuthorId
 	form.Topic = post.TopicId
+	if !post.StickyExpired.IsZero() {
+		form.StickyExpired = utils.Date(post.StickyExpired, setting.DateTimeFormat)
+	}
 }
 
 func (form *PostAdminForm) SetToPost(post *models.Post) {
@@ -178,6 +389,10 @@ func (form *PostAdminForm) SetToPost(post *models.Post) {
 	post.LastReplyId = form.LastReply
 	post.LastAuthorId = form.LastAuthor
 	post.TopicId = form.Topic
+	post.StickyExpired = time.Time{}
+	if len(form.StickyExpired) > 0 && form.Sticky != setting.STICKY_NONE {
+		post.StickyExpired, _ = utils.DateParse(form.StickyExpired, setting.DateTimeFormat)
+	}
 	//get category
 	if topic, err := models.GetTopicById(form.Topic); err == nil {
 		post.CategoryId = topic.CategoryId
@@ -187,6 +402,7 @@ func (form *PostAdminForm) SetToPost(post *models.Post) {
 
 type CommentForm struct {
 	Message string `form:"type(textarea,markdown)" valid:"Required;MinSize(5)"`
+	Parent  int64  `form:"type(hidden)"`
 }
 
 func (form *CommentForm) SaveComment(comment *models.Comment, user *models.User, post *models.Post) error {
@@ -194,18 +410,35 @@ func (form *CommentForm) SaveComment(comment *models.Comment, user *models.User,
 	comment.MessageCache = utils.RenderMarkdown(form.Message)
 	comment.UserId = user.Id
 	comment.PostId = post.Id
+	// only reply to the living comments of the same post
+	if form.Parent > 0 {
+		if parent, err := models.GetCommentById(form.Parent); err == nil && parent.PostId == post.Id && !parent.IsDelete {
+			comment.ParentId = parent.Id
+		}
+	}
 	if err := models.InsertComment(comment); err == nil {
 		post.LastReplyId = user.Id
-		models.UpdateById(post.Id, post, "last_reply_id", "last_replied")
-
-		cnt, _ := models.CountCommentsLTEId(comment.Id)
-		comment.Floor = int(cnt)
-		return models.UpdateById(comment.Id, comment, "floor")
+		return models.UpdateById(post.Id, post, "last_reply_id", "last_replied")
 	} else {
 		return err
 	}
 }
 
+func (form *CommentForm) SetFromComment(comment *models.Comment) {
+	form.Message = comment.Message
+}
+
+func (form *CommentForm) UpdateComment(comment *models.Comment) error {
+	if comment.Message == form.Message {
+		return nil
+	}
+	comment.Message = form.Message
+	comment.MessageCache = utils.RenderMarkdown(form.Message)
+	comment.EditTimes++
+	comment.Edited = time.Now()
+	return models.UpdateById(comment.Id, comment, "message", "message_cache", "edit_times", "edited")
+}
+
 type CommentAdminForm struct {
 	Create  bool   `form:"-"`
 	User    int    `form:"attr(rel,select2-admin-model);attr(data-model,User)" valid:"Required"`
@@ -0,0 +1,35 @@
+package post
+
+import (
+	"time"
+
+	"github.com/lunny/log"
+	"github.com/missdeer/wego/models"
+)
+
+// publish the scheduled posts whose time has come, the posts are published
+// as new ones and the hooks of new posts are called
+func publishScheduledPosts() {
+	posts, err := models.FindDuePosts(time.Now())
+	if err != nil {
+		log.Error("FindDuePosts: ", err)
+		return
+	}
+	for i := range posts {
+		post := &posts[i]
+		published, err := models.PublishPost(post)
+		if err != nil {
+			log.Error("PublishPost: ", err)
+			continue
+		}
+		if !published {
+			continue
+		}
+		user, err := models.GetUserById(post.UserId)
+		if err != nil {
+			log.Error("GetUserById: ", err)
+			continue
+		}
+		PostPublished(user, post)
+	}
+}
@@ -0,0 +1,21 @@
+package post
+
+import (
+	"time"
+
+	"github.com/lunny/log"
+	"github.com/missdeer/wego/models"
+)
+
+// Init unpins the expired sticky posts and publishes the scheduled posts
+// every minute.
+func Init() {
+	go func() {
+		for range time.Tick(time.Minute) {
+			if err := models.ExpireStickyPosts(); err != nil {
+				log.Error("ExpireStickyPosts: ", err)
+			}
+			publishScheduledPosts()
+		}
+	}()
+}
@@ -0,0 +1,66 @@
+package post
+
+import (
+	"github.com/go-xweb/xweb/validation"
+	"github.com/missdeer/wego/models"
+)
+
+type TagAdminForm struct {
+	Create  bool   `form:"-"`
+	Id      int64  `form:"-"`
+	Name    string `valid:"Required;MaxSize(30)"`
+	Slug    string `valid:"Required;MaxSize(100)"`
+	Intro   string `form:"type(textarea)"`
+	Synonym int64  `form:"attr(rel,select2-admin-model);attr(data-model,Tag);attr(data-min-input,1)" valid:""`
+}
+
+func (form *TagAdminForm) Valid(v *validation.Validation) {
+	if models.NormalizeTagName(form.Name) != form.Name {
+		v.SetError("Name", "post.invalid_tag")
+	} else if tag, err := models.GetTagByName(form.Name); err == nil && tag.Id != form.Id {
+		v.SetError("Name", "admin.tag_name_exists")
+	}
+
+	if tag, err := models.GetTagBySlug(form.Slug); err == nil && tag.Id != form.Id {
+		v.SetError("Slug", "admin.tag_slug_exists")
+	}
+
+	if form.Synonym > 0 {
+		if form.Synonym == form.Id {
+			v.SetError("Synonym", "admin.tag_synonym_self")
+		} else if _, err := models.GetTagById(form.Synonym); err != nil {
+			v.SetError("Synonym", "admin.not_found_by_id")
+		}
+	}
+}
+
+func (form *TagAdminForm) Labels() map[string]string {
+	return map[string]string{
+		"Name":    "model.tag_name",
+		"Slug":    "model.tag_slug",
+		"Intro":   "model.tag_intro",
+		"Synonym": "model.tag_synonym",
+	}
+}
+
+func (form *TagAdminForm) Helps() map[string]string {
+	return map[string]string{
+		"Synonym": "admin.tag_synonym_help",
+	}
+}
+
+func (form *TagAdminForm) SetFromTag(tag *models.Tag) {
+	form.Id = tag.Id
+	form.Name = tag.Name
+	form.Slug = tag.Slug
+	form.Intro = tag.Intro
+	form.Synonym = tag.SynonymId
+}
+
+// SetToTag sets the fields of tag except the synonym,
+// which is changed by merging tags.
+func (form *TagAdminForm) SetToTag(tag *models.Tag) {
+	tag.Name = form.Name
+	tag.Slug = form.Slug
+	tag.Intro = form.Intro
+}
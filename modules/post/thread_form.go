@@ -0,0 +1,88 @@
+package post
+
+import (
+	"github.com/go-xweb/xweb/validation"
+	"github.com/missdeer/wego/models"
+	"github.com/missdeer/wego/modules/utils"
+)
+
+func topicSelectData(topics []models.Topic) [][]string {
+	data := make([][]string, 0, len(topics))
+	for _, topic := range topics {
+		data = append(data, []string{topic.Name, utils.ToStr(topic.Id)})
+	}
+	return data
+}
+
+func validTopic(v *validation.Validation, topics []models.Topic, id int64) {
+	for _, topic := range topics {
+		if topic.Id == id {
+			return
+		}
+	}
+	v.SetError("Topic", "post.moderate_topic_not_allowed")
+}
+
+type PostMoveForm struct {
+	Topic     int64          `form:"type(select);attr(rel,select2)" valid:"Required"`
+	LeaveNote bool           ``
+	Topics    []models.Topic `form:"-"`
+}
+
+func (form *PostMoveForm) TopicSelectData() [][]string {
+	return topicSelectData(form.Topics)
+}
+
+func (form *PostMoveForm) Valid(v *validation.Validation) {
+	validTopic(v, form.Topics, form.Topic)
+}
+
+func (form *PostMoveForm) Labels() map[string]string {
+	return map[string]string{
+		"Topic":     "model.topic",
+		"LeaveNote": "post.moderate_leave_note",
+	}
+}
+
+type PostSplitForm struct {
+	Title  string         `form:"attr(autocomplete,off)" valid:"Required;MinSize(5);MaxSize(60)"`
+	Topic  int64          `form:"type(select);attr(rel,select2)" valid:"Required"`
+	Topics []models.Topic `form:"-"`
+}
+
+func (form *PostSplitForm) TopicSelectData() [][]string {
+	return topicSelectData(form.Topics)
+}
+
+func (form *PostSplitForm) Valid(v *validation.Validation) {
+	validTopic(v, form.Topics, form.Topic)
+}
+
+func (form *PostSplitForm) Labels() map[string]string {
+	return map[string]string{
+		"Title": "model.post_title",
+		"Topic": "model.topic",
+	}
+}
+
+func (form *PostSplitForm) Helps() map[string]string {
+	return map[string]string{
+		"Title": "post.moderate_split_help",
+	}
+}
+
+type PostMergeForm struct {
+	Target int64 `valid:"Required"`
+}
+
+func (form *PostMergeForm) Labels() map[string]string {
+	return map[string]string{
+		"Target": "post.moderate_merge_target",
+	}
+}
+
+func (form *PostMergeForm) Helps() map[string]string {
+	return map[string]string{
+		"Target": "post.moderate_merge_help",
+	}
+}
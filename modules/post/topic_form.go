@@ -76,18 +76,20 @@ func (form *TopicAdminForm) SetToTopic(topic *models.Topic) {
 }
 
 type CategoryAdminForm struct {
-	Create bool   `form:"-"`
-	Id     int    `form:"-"`
-	Name   string `valid:"Required;MaxSize(30)"`
-	Slug   string `valid:"Required;MaxSize(100)"`
-	Order  int    ``
+	Create     bool   `form:"-"`
+	Id         int    `form:"-"`
+	Name       string `valid:"Required;MaxSize(30)"`
+	Slug       string `valid:"Required;MaxSize(100)"`
+	Order      int    ``
+	IsQuestion bool   ``
 }
 
 func (form *CategoryAdminForm) Labels() map[string]string {
 	return map[string]string{
-		"Name":  "model.category_name",
-		"Slug":  "model.category_slug",
-		"Order": "model.category_order",
+		"Name":       "model.category_name",
+		"Slug":       "model.category_slug",
+		"Order":      "model.category_order",
+		"IsQuestion": "model.category_is_question",
 	}
 }
 
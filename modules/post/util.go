@@ -51,6 +51,15 @@ func FilterMentions(user *models.User, content string) {
 	// }
 }
 
+// PostPublished is called once the post goes public, when it is created
+// or when the scheduled time of it comes. It is the hook of new posts,
+// there are no webhooks or notifications of new posts to fire yet and the
+// mention mails of FilterMentions are still a TODO.
+func PostPublished(user *models.User, post *models.Post) {
+	// mentioned follow users
+	FilterMentions(user, post.ContentCache)
+}
+
 func PostBrowsersAdd(uid int64, ip string, post *models.Post) {
 	var key string
 	if uid == 0 {
@@ -82,25 +91,21 @@ func PostReplysCount(post *models.Post) {
 	}
 }
 
-func FilterCommentMentions(fromUser *models.User, post *models.Post, comment *models.Comment) {
-	var uri = fmt.Sprintf("post/%d", post.Id)
-	var lang = setting.DefaultLang
+// users to notify of the comment, the post author, the author of
+// the replied comment and the mentioned users
+func commentNotifyUsers(fromUser *models.User, post *models.Post, comment *models.Comment) []int64 {
+	var userIds []int64
 	if fromUser.Id != post.UserId {
-		var notification = models.Notification{
-			FromUserId:   fromUser.Id,
-			ToUserId:     post.UserId,
-			Action:       setting.NOTICE_TYPE_COMMENT,
-			Title:        post.Title,
-			TargetId:     post.Id,
-			Uri:          uri,
-			Lang:         lang,
-			Floor:        comment.Floor,
-			Content:      comment.Message,
-			ContentCache: comment.MessageCache,
-			Status:       setting.NOTICE_UNREAD,
-		}
-		if err := models.InsertNotification(&notification); err == nil {
-			//pass
+		userIds = append(userIds, post.UserId)
+	}
+
+	seen := make(map[int64]bool)
+	if comment.ParentId > 0 {
+		if parent, err := models.GetCommentById(comment.ParentId); err == nil {
+			if parent.UserId != fromUser.Id && parent.UserId != post.UserId {
+				seen[parent.UserId] = true
+				userIds = append(userIds, parent.UserId)
+			}
 		}
 	}
 
@@ -112,24 +117,74 @@ func FilterCommentMentions(fromUser *models.User, post *models.Post, comment *mo
 		bUserName := strings.TrimPrefix(strings.TrimSpace(userName), "@")
 
 		if user, err := models.GetUserByName(bUserName); err == nil {
-			if user.Id != 0 && user.Id != post.UserId {
-				notification := models.Notification{
-					FromUserId:   fromUser.Id,
-					ToUserId:     user.Id,
-					Action:       setting.NOTICE_TYPE_COMMENT,
-					Title:        post.Title,
-					TargetId:     post.Id,
-					Uri:          uri,
-					Lang:         lang,
-					Floor:        comment.Floor,
-					Content:      comment.Message,
-					ContentCache: comment.MessageCache,
-					Status:       setting.NOTICE_UNREAD,
-				}
-				if err := models.InsertNotification(&notification); err == nil {
-					//pass
-				}
+			if user.Id != 0 && user.Id != post.UserId && !seen[user.Id] {
+				seen[user.Id] = true
+				userIds = append(userIds, user.Id)
+			}
+		}
+	}
+	return userIds
+}
+
+func commentNotification(fromUser *models.User, toUserId int64, post *models.Post, comment *models.Comment) models.Notification {
+	return models.Notification{
+		FromUserId:   fromUser.Id,
+		ToUserId:     toUserId,
+		Action:       setting.NOTICE_TYPE_COMMENT,
+		Title:        post.Title,
+		TargetId:     post.Id,
+		Uri:          fmt.Sprintf("post/%d", post.Id),
+		Lang:         setting.DefaultLang,
+		Floor:        comment.Floor,
+		Content:      comment.Message,
+		ContentCache: comment.MessageCache,
+		Status:       setting.NOTICE_UNREAD,
+	}
+}
+
+func FilterCommentMentions(fromUser *models.User, post *models.Post, comment *models.Comment) {
+	for _, userId := range commentNotifyUsers(fromUser, post, comment) {
+		notification := commentNotification(fromUser, userId, post, comment)
+		if err := models.InsertNotification(&notification); err == nil {
+			//pass
+		}
+	}
+}
+
+// UpdateCommentMentions re-evaluates the notifications of an edited comment,
+// users no longer mentioned lose theirs, newly mentioned users get notified
+// and the others keep their notification with the new content.
+func UpdateCommentMentions(fromUser *models.User, post *models.Post, comment *models.Comment) {
+	notifications, err := models.FindCommentNotifications(comment)
+	if err != nil {
+		log.Error("UpdateCommentMentions ", err)
+		return
+	}
+
+	notified := make(map[int64]*models.Notification, len(notifications))
+	for _, notification := range notifications {
+		notified[notification.ToUserId] = notification
+	}
+
+	for _, userId := range commentNotifyUsers(fromUser, post, comment) {
+		if notification, ok := notified[userId]; ok {
+			delete(notified, userId)
+			notification.Content = comment.Message
+			notification.ContentCache = comment.MessageCache
+			if err := models.UpdateById(notification.Id, notification, "content", "content_cache"); err != nil {
+				log.Error("UpdateCommentMentions ", err)
 			}
+			continue
+		}
+		notification := commentNotification(fromUser, userId, post, comment)
+		if err := models.InsertNotification(&notification); err != nil {
+			log.Error("UpdateCommentMentions ", err)
+		}
+	}
+
+	for _, notification := range notified {
+		if err := models.DeleteById(notification.Id, new(models.Notification)); err != nil {
+			log.Error("UpdateCommentMentions ", err)
 		}
 	}
 }
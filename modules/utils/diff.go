@@ -0,0 +1,198 @@
+package utils
+
+import "strings"
+
+const (
+	DiffEqual = iota
+	DiffInsert
+	DiffDelete
+)
+
+type DiffLine struct {
+	Type int
+	Text string
+}
+
+func (d DiffLine) IsEqual() bool {
+	return d.Type == DiffEqual
+}
+
+func (d DiffLine) IsInsert() bool {
+	return d.Type == DiffInsert
+}
+
+func (d DiffLine) IsDelete() bool {
+	return d.Type == DiffDelete
+}
+
+func splitLines(s string) []string {
+	s = strings.Replace(s, "\r\n", "\n", -1)
+	if len(s) == 0 {
+		return nil
+	}
+	return strings.Split(s, "\n")
+}
+
+// texts longer than these are not diffed, see DiffTooLarge
+const (
+	DiffMaxLines = 5000
+	DiffMaxBytes = 256 * 1024
+)
+
+// DiffTooLarge checks if the texts are too large to diff on a request.
+func DiffTooLarge(a, b string) bool {
+	return len(a)+len(b) > DiffMaxBytes ||
+		strings.Count(a, "\n")+strings.Count(b, "\n") > DiffMaxLines
+}
+
+// DiffLines compares two texts line by line with the linear space
+// Myers algorithm, check the size with DiffTooLarge first.
+func DiffLines(a, b string) []DiffLine {
+	as, bs := splitLines(a), splitLines(b)
+	return diffLines(as, bs, make([]DiffLine, 0, len(as)+len(bs)))
+}
+
+func diffLines(as, bs []string, lines []DiffLine) []DiffLine {
+	// common prefix and suffix
+	for len(as) > 0 && len(bs) > 0 && as[0] == bs[0] {
+		lines = append(lines, DiffLine{DiffEqual, as[0]})
+		as, bs = as[1:], bs[1:]
+	}
+	k := 0
+	for k < len(as) && k < len(bs) && as[len(as)-1-k] == bs[len(bs)-1-k] {
+		k++
+	}
+	suffix := as[len(as)-k:]
+	as, bs = as[:len(as)-k], bs[:len(bs)-k]
+
+	if x, y := middleSnake(as, bs); x >= 0 {
+		lines = diffLines(as[:x], bs[:y], lines)
+		lines = diffLines(as[x:], bs[y:], lines)
+	} else {
+		for _, line := range as {
+			lines = append(lines, DiffLine{DiffDelete, line})
+		}
+		for _, line := range bs {
+			lines = append(lines, DiffLine{DiffInsert, line})
+		}
+	}
+
+	for _, line := range suffix {
+		lines = append(lines, DiffLine{DiffEqual, line})
+	}
+	return lines
+}
+
+// middleSnake finds the point where the forward and the backward shortest
+// edit paths meet, -1 is returned when as and bs have nothing in common.
+// as and bs have no common prefix and suffix.
+func middleSnake(as, bs []string) (int, int) {
+	n, m := len(as), len(bs)
+	if n == 0 || m == 0 {
+		return -1, -1
+	}
+	maxD := (n + m + 1) / 2
+	offset := maxD
+	vf := make([]int, 2*maxD+2)
+	vb := make([]int, 2*maxD+2)
+	for i := range vf {
+		vf[i], vb[i] = -1, -1
+	}
+	vf[offset+1], vb[offset+1] = 0, 0
+
+	delta := n - m
+	front := delta%2 != 0
+	// the diagonals which run off the edit graph are skipped
+	fStart, fEnd, bStart, bEnd := 0, 0, 0, 0
+	for d := 0; d < maxD; d++ {
+		for k := -d + fStart; k <= d-fEnd; k += 2 {
+			i := offset + k
+			var x int
+			if k == -d || (k != d && vf[i-1] < vf[i+1]) {
+				x = vf[i+1]
+			} else {
+				x = vf[i-1] + 1
+			}
+			y := x - k
+			for x < n && y < m && as[x] == bs[y] {
+				x++
+				y++
+			}
+			vf[i] = x
+			if x > n {
+				fEnd += 2
+			} else if y > m {
+				fStart += 2
+			} else if front {
+				if j := offset + delta - k; j >= 0 && j < len(vb) && vb[j] != -1 && x >= n-vb[j] {
+					return x, y
+				}
+			}
+		}
+		for k := -d + bStart; k <= d-bEnd; k += 2 {
+			i := offset + k
+			var x int
+			if k == -d || (k != d && vb[i-1] < vb[i+1]) {
+				x = vb[i+1]
+			} else {
+				x = vb[i-1] + 1
+			}
+			y := x - k
+			for x < n && y < m && as[n-x-1] == bs[m-y-1] {
+				x++
+				y++
+			}
+			vb[i] = x
+			if x > n {
+				bEnd += 2
+			} else if y > m {
+				bStart += 2
+			} else if !front {
+				if j := offset + delta - k; j >= 0 && j < len(vf) && vf[j] != -1 && vf[j] >= n-x {
+					return vf[j], offset + vf[j] - j
+				}
+			}
+		}
+	}
+	return -1, -1
+}
+
+// BlameLine is a line of the latest text with the index of the text which added it.
+type BlameLine struct {
+	Text  string
+	Index int
+}
+
+// BlameLines attributes the lines of the last text to the texts which added
+// them, texts are the revisions from the oldest one.
+func BlameLines(texts []string) []BlameLine {
+	var lines []BlameLine
+	var prev string
+	for i, text := range texts {
+		next := make([]BlameLine, 0, len(lines))
+		// texts too large to diff are taken as rewritten
+		if DiffTooLarge(prev, text) {
+			for _, line := range splitLines(text) {
+				next = append(next, BlameLine{line, i})
+			}
+			lines = next
+			prev = text
+			continue
+		}
+		k := 0
+		for _, d := range DiffLines(prev, text) {
+			switch d.Type {
+			case DiffEqual:
+				next = append(next, lines[k])
+				k++
+			case DiffDelete:
+				k++
+			case DiffInsert:
+				next = append(next, BlameLine{d.Text, i})
+			}
+		}
+		lines = next
+		prev = text
+	}
+	return lines
+}
@@ -0,0 +1,57 @@
+package utils
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDiffLines(t *testing.T) {
+	lines := DiffLines("a\nb\nc", "a\r\nc\nd")
+	ThrowFailNow(t, AssertIs(len(lines), 4))
+	ThrowFail(t, AssertIs(lines[0].IsEqual() && lines[0].Text == "a", true))
+	ThrowFail(t, AssertIs(lines[1].IsDelete() && lines[1].Text == "b", true))
+	ThrowFail(t, AssertIs(lines[2].IsEqual() && lines[2].Text == "c", true))
+	ThrowFail(t, AssertIs(lines[3].IsInsert() && lines[3].Text == "d", true))
+
+	lines = DiffLines("", "x")
+	ThrowFailNow(t, AssertIs(len(lines), 1))
+	ThrowFail(t, AssertIs(lines[0].IsInsert(), true))
+
+	ThrowFail(t, AssertIs(len(DiffLines("", "")), 0))
+
+	// nothing in common
+	lines = DiffLines("a\nb", "c")
+	ThrowFailNow(t, AssertIs(len(lines), 3))
+	ThrowFail(t, AssertIs(lines[0].IsDelete() && lines[1].IsDelete() && lines[2].IsInsert(), true))
+
+	// the shortest edit keeps the longest common lines
+	lines = DiffLines("x\na\nb\nc\ny", "z\na\nc\nb\nw")
+	equal := 0
+	for _, line := range lines {
+		if line.IsEqual() {
+			equal++
+		}
+	}
+	ThrowFail(t, AssertIs(equal, 2))
+}
+
+func TestDiffTooLarge(t *testing.T) {
+	ThrowFail(t, AssertIs(DiffTooLarge("a\nb", "c"), false))
+	ThrowFail(t, AssertIs(DiffTooLarge(strings.Repeat("a\n", DiffMaxLines+1), ""), true))
+	ThrowFail(t, AssertIs(DiffTooLarge(strings.Repeat("a", DiffMaxBytes+1), ""), true))
+}
+
+func TestBlameLines(t *testing.T) {
+	lines := BlameLines([]string{"a\nb", "a\nc\nb", "a\nc"})
+	ThrowFailNow(t, AssertIs(len(lines), 2))
+	ThrowFail(t, AssertIs(lines[0].Text == "a" && lines[0].Index == 0, true))
+	ThrowFail(t, AssertIs(lines[1].Text == "c" && lines[1].Index == 1, true))
+
+	ThrowFail(t, AssertIs(len(BlameLines(nil)), 0))
+
+	// texts too large to diff are attributed to the revision as a whole
+	large := "a\n" + strings.Repeat("b", DiffMaxBytes)
+	lines = BlameLines([]string{"a", large})
+	ThrowFailNow(t, AssertIs(len(lines), 2))
+	ThrowFail(t, AssertIs(lines[0].Text == "a" && lines[0].Index == 1, true))
+}
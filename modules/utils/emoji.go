@@ -0,0 +1,92 @@
+package utils
+
+import (
+	"regexp"
+	"sync"
+)
+
+var emojisMu sync.RWMutex
+
+// emoji of the common shortcodes
+var emojis = map[string]string{
+	"smile":            "\U0001F604",
+	"smiley":           "\U0001F603",
+	"grinning":         "\U0001F600",
+	"laughing":         "\U0001F606",
+	"joy":              "\U0001F602",
+	"wink":             "\U0001F609",
+	"blush":            "\U0001F60A",
+	"heart_eyes":       "\U0001F60D",
+	"sunglasses":       "\U0001F60E",
+	"thinking":         "\U0001F914",
+	"neutral_face":     "\U0001F610",
+	"confused":         "\U0001F615",
+	"disappointed":     "\U0001F61E",
+	"cry":              "\U0001F622",
+	"sob":              "\U0001F62D",
+	"angry":            "\U0001F620",
+	"scream":           "\U0001F631",
+	"sweat_smile":      "\U0001F605",
+	"stuck_out_tongue": "\U0001F61B",
+	"innocent":         "\U0001F607",
+	"+1":               "\U0001F44D",
+	"thumbsup":         "\U0001F44D",
+	"-1":               "\U0001F44E",
+	"thumbsdown":       "\U0001F44E",
+	"clap":             "\U0001F44F",
+	"pray":             "\U0001F64F",
+	"wave":             "\U0001F44B",
+	"ok_hand":          "\U0001F44C",
+	"muscle":           "\U0001F4AA",
+	"eyes":             "\U0001F440",
+	"heart":            "❤️",
+	"broken_heart":     "\U0001F494",
+	"star":             "⭐",
+	"fire":             "\U0001F525",
+	"sparkles":         "✨",
+	"tada":             "\U0001F389",
+	"rocket":           "\U0001F680",
+	"bug":              "\U0001F41B",
+	"beer":             "\U0001F37A",
+	"coffee":           "☕",
+	"100":              "\U0001F4AF",
+	"warning":          "⚠️",
+	"x":                "❌",
+	"white_check_mark": "✅",
+	"heavy_check_mark": "✔️",
+	"question":         "❓",
+	"exclamation":      "❗",
+	"bulb":             "\U0001F4A1",
+	"memo":             "\U0001F4DD",
+	"lock":             "\U0001F512",
+	"zap":              "⚡",
+	"gopher":           "\U0001F439",
+}
+
+// RegisterEmoji adds or replaces the emoji of shortcode.
+func RegisterEmoji(code, emoji string) {
+	emojisMu.Lock()
+	defer emojisMu.Unlock()
+	emojis[code] = emoji
+}
+
+func getEmoji(code string) (string, bool) {
+	emojisMu.RLock()
+	defer emojisMu.RUnlock()
+	emoji, ok := emojis[code]
+	return emoji, ok
+}
+
+func init() {
+	RegisterMarkdownRule(&MarkdownRule{
+		Name:    "emoji",
+		Pattern: regexp.MustCompile(`:([a-z0-9_+\-]+):`),
+		Render: func(m []string) (string, bool) {
+			emoji, ok := getEmoji(m[1])
+			if !ok {
+				return "", false
+			}
+			return `<span class="emoji" title=":` + m[1] + `:">` + emoji + `</span>`, true
+		},
+	})
+}
@@ -0,0 +1,108 @@
+package utils
+
+import (
+	"bytes"
+	"go/format"
+	"html/template"
+	"regexp"
+	"strings"
+
+	"github.com/missdeer/wego/setting"
+)
+
+// import paths of the standard packages which are linked from inline code
+var goStdPackages = map[string]bool{}
+
+// names of the standard packages for qualified identifiers, ambiguous
+// names are resolved to the common package
+var goStdNames = map[string]string{
+	"rand":     "math/rand",
+	"template": "text/template",
+	"scanner":  "text/scanner",
+	"pprof":    "runtime/pprof",
+}
+
+func init() {
+	for _, path := range []string{
+		"archive/tar", "archive/zip", "bufio", "bytes", "compress/gzip", "compress/zlib",
+		"container/heap", "container/list", "container/ring", "context", "crypto",
+		"crypto/aes", "crypto/cipher", "crypto/hmac", "crypto/md5", "crypto/rand",
+		"crypto/rsa", "crypto/sha1", "crypto/sha256", "crypto/sha512", "crypto/tls",
+		"crypto/x509", "database/sql", "database/sql/driver", "debug/elf", "embed",
+		"encoding", "encoding/base64", "encoding/binary", "encoding/csv", "encoding/gob",
+		"encoding/hex", "encoding/json", "encoding/pem", "encoding/xml", "errors",
+		"expvar", "flag", "fmt", "go/ast", "go/build", "go/format", "go/parser",
+		"go/printer", "go/scanner", "go/token", "go/types", "hash", "hash/crc32",
+		"hash/fnv", "html", "html/template", "image", "image/color", "image/draw",
+		"image/gif", "image/jpeg", "image/png", "io", "io/fs", "io/ioutil", "log",
+		"log/syslog", "math", "math/big", "math/bits", "math/rand", "mime",
+		"mime/multipart", "net", "net/http", "net/http/httptest", "net/http/httputil",
+		"net/http/pprof", "net/mail", "net/rpc", "net/smtp", "net/textproto", "net/url",
+		"os", "os/exec", "os/signal", "os/user", "path", "path/filepath", "plugin",
+		"reflect", "regexp", "runtime", "runtime/debug", "runtime/pprof", "sort",
+		"strconv", "strings", "sync", "sync/atomic", "syscall", "testing",
+		"text/scanner", "text/tabwriter", "text/template", "time", "unicode",
+		"unicode/utf16", "unicode/utf8", "unsafe",
+	} {
+		goStdPackages[path] = true
+		name := path[strings.LastIndex(path, "/")+1:]
+		if _, ok := goStdNames[name]; !ok {
+			goStdNames[name] = path
+		}
+	}
+
+	RegisterMarkdownRule(&MarkdownRule{
+		Name:    "go-import",
+		Pattern: regexp.MustCompile(`^"?((?:[a-z0-9\-]+\.)+[a-z]+(?:/[\w\-.~]+)+|[a-z0-9]+(?:/[a-z0-9]+)*)"?$`),
+		Render: func(m []string) (string, bool) {
+			path := m[1]
+			if !goStdPackages[path] && !strings.Contains(strings.SplitN(path, "/", 2)[0], ".") {
+				return "", false
+			}
+			// single words are too common unless they are quoted like imports
+			if !strings.Contains(path, "/") && !strings.HasPrefix(m[0], `"`) {
+				return "", false
+			}
+			return goDocLink(path, "", m[0])
+		},
+		InCode: true,
+	})
+	RegisterMarkdownRule(&MarkdownRule{
+		Name:    "go-identifier",
+		Pattern: regexp.MustCompile(`^([a-z][a-z0-9]*)\.([A-Z]\w*(?:\.[A-Z]\w*)?)(?:\(\))?$`),
+		Render: func(m []string) (string, bool) {
+			path, ok := goStdNames[m[1]]
+			if !ok {
+				return "", false
+			}
+			return goDocLink(path, m[2], m[0])
+		},
+		InCode: true,
+	})
+}
+
+// link to the documentation of package, or of the identifier in package
+func goDocLink(path, name, text string) (string, bool) {
+	if len(setting.GoDocURL) == 0 {
+		return "", false
+	}
+	link := strings.Replace(setting.GoDocURL, "{path}", path, -1)
+	if len(name) > 0 {
+		link += "#" + name
+	}
+	return `<a href="` + template.HTMLEscapeString(link) + `">` + template.HTMLEscapeString(text) + `</a>`, true
+}
+
+// write the badge of whether the Go code is formatted by gofmt,
+// nothing is written for code which can't be parsed
+func writeGoFmtBadge(out *bytes.Buffer, src []byte) {
+	formatted, err := format.Source(src)
+	if err != nil {
+		return
+	}
+	if bytes.Equal(bytes.TrimSpace(formatted), bytes.TrimSpace(src)) {
+		out.WriteString(`<div class="gofmt gofmt-ok" title="formatted by gofmt">gofmt &#10003;</div>`)
+	} else {
+		out.WriteString(`<div class="gofmt gofmt-diff" title="not formatted by gofmt">gofmt &#10007;</div>`)
+	}
+}
@@ -0,0 +1,250 @@
+package utils
+
+import (
+	"bytes"
+	"go/scanner"
+	"go/token"
+	"html/template"
+	"strings"
+
+	"github.com/alecthomas/chroma"
+	"github.com/alecthomas/chroma/lexers"
+	"github.com/missdeer/wego/setting"
+	"github.com/russross/blackfriday"
+)
+
+// markdown renderer which highlights the fenced code by its language
+type highlightRenderer struct {
+	blackfriday.Renderer
+}
+
+func (r *highlightRenderer) BlockCode(out *bytes.Buffer, text []byte, lang string) {
+	if fields := strings.Fields(lang); len(fields) > 0 {
+		lang = strings.ToLower(fields[0])
+	}
+	if len(lang) == 0 {
+		r.Renderer.BlockCode(out, text, lang)
+		return
+	}
+
+	var code bytes.Buffer
+	switch lang {
+	case "go", "golang":
+		lang = "go"
+		highlightGo(&code, text)
+	default:
+		if !highlightCode(&code, text, lang) {
+			r.Renderer.BlockCode(out, text, lang)
+			return
+		}
+	}
+
+	if out.Len() > 0 {
+		out.WriteByte('\n')
+	}
+	if lang == "go" && setting.GoFmtBadge {
+		writeGoFmtBadge(out, text)
+	}
+	out.WriteString(`<pre class="highlight"><code class="language-`)
+	template.HTMLEscape(out, []byte(lang))
+	out.WriteString(`">`)
+	out.Write(code.Bytes())
+	out.WriteString("</code></pre>\n")
+}
+
+// write the text as a span of the css class
+func writeToken(out *bytes.Buffer, class string, text []byte) {
+	if len(class) == 0 {
+		template.HTMLEscape(out, text)
+		return
+	}
+	out.WriteString(`<span class="`)
+	out.WriteString(class)
+	out.WriteString(`">`)
+	template.HTMLEscape(out, text)
+	out.WriteString("</span>")
+}
+
+// highlight code by the lexer of language, false is returned for unknown languages
+func highlightCode(out *bytes.Buffer, text []byte, lang string) bool {
+	lexer := lexers.Get(lang)
+	if lexer == nil {
+		return false
+	}
+	it, err := chroma.Coalesce(lexer).Tokenise(nil, string(text))
+	if err != nil {
+		return false
+	}
+	for t := it(); t != chroma.EOF; t = it() {
+		writeToken(out, tokenClass(t.Type), []byte(t.Value))
+	}
+	return true
+}
+
+// css class of token type, the class of its category is used for types without one
+func tokenClass(t chroma.TokenType) string {
+	if t == chroma.Text || t == chroma.Whitespace {
+		return ""
+	}
+	for _, tt := range []chroma.TokenType{t, t.SubCategory(), t.Category()} {
+		if class, ok := chroma.StandardTypes[tt]; ok {
+			return class
+		}
+	}
+	return ""
+}
+
+var (
+	goTypes = map[string]bool{
+		"bool": true, "byte": true, "complex64": true, "complex128": true, "error": true,
+		"float32": true, "float64": true, "int": true, "int8": true, "int16": true,
+		"int32": true, "int64": true, "rune": true, "string": true, "uint": true,
+		"uint8": true, "uint16": true, "uint32": true, "uint64": true, "uintptr": true,
+	}
+	goConstants = map[string]bool{
+		"true": true, "false": true, "nil": true, "iota": true,
+	}
+	goBuiltins = map[string]bool{
+		"append": true, "cap": true, "close": true, "complex": true, "copy": true,
+		"delete": true, "imag": true, "len": true, "make": true, "new": true,
+		"panic": true, "print": true, "println": true, "real": true, "recover": true,
+	}
+)
+
+// highlightGo marks up Go code by the tokens of Go scanner, so snippets
+// which don't parse, like statements without a function, are highlighted
+// as well. Illegal tokens are marked as errors.
+func highlightGo(out *bytes.Buffer, src []byte) {
+	fset := token.NewFileSet()
+	file := fset.AddFile("", fset.Base(), len(src))
+
+	var s scanner.Scanner
+	s.Init(file, src, func(token.Position, string) {}, scanner.ScanComments)
+
+	var last int
+	var prev token.Token
+	for {
+		pos, tok, lit := s.Scan()
+		if tok == token.EOF {
+			break
+		}
+		// semicolons inserted at the end of lines are not in the source
+		if tok == token.SEMICOLON && lit == "\n" {
+			continue
+		}
+
+		offset := file.Offset(pos)
+		if offset < last {
+			continue
+		}
+		end := goTokenEnd(src, offset, tok, lit)
+		template.HTMLEscape(out, src[last:offset])
+		writeToken(out, goTokenClass(tok, lit, prev), src[offset:end])
+		last = end
+		if tok != token.COMMENT {
+			prev = tok
+		}
+	}
+	template.HTMLEscape(out, src[last:])
+}
+
+// end offset of token in source, the scanner drops the carriage returns
+// of raw strings and comments so their ends are searched in the source,
+// unterminated ones run to the end of source
+func goTokenEnd(src []byte, offset int, tok token.Token, lit string) int {
+	text := lit
+	if len(text) == 0 {
+		text = tok.String()
+	}
+	end := offset + len(text)
+	if !bytes.HasPrefix(src[offset:], []byte(text)) {
+		var opening, closing string
+		switch {
+		case strings.HasPrefix(text, "`"):
+			opening, closing = "`", "`"
+		case strings.HasPrefix(text, "/*"):
+			opening, closing = "/*", "*/"
+		case strings.HasPrefix(text, "//"):
+			opening, closing = "//", "\n"
+		}
+		if len(closing) > 0 {
+			start := offset + len(opening)
+			if i := bytes.Index(src[start:], []byte(closing)); i >= 0 {
+				end = start + i + len(closing)
+				if closing == "\n" {
+					end--
+				}
+			} else {
+				end = len(src)
+			}
+		}
+	}
+	if end > len(src) {
+		end = len(src)
+	}
+	return end
+}
+
+// css classes of the tokens of Go code
+var goTokenClasses = []string{
+	"c", "kn", "kd", "kt", "k", "nf", "kc", "nb", "n", "mi", "mf", "sc", "s", "err", "p", "o",
+}
+
+// highlightClasses returns the css classes of the highlighted tokens, which
+// are the classes of Go tokens and of the token types of the lexers.
+func highlightClasses() []string {
+	classes := append([]string(nil), goTokenClasses...)
+	for t, class := range chroma.StandardTypes {
+		// negative types are the parts of the formatter, not tokens
+		if t < 0 || t == chroma.Text || t == chroma.Whitespace || len(class) == 0 {
+			continue
+		}
+		classes = append(classes, class)
+	}
+	return classes
+}
+
+func goTokenClass(tok token.Token, lit string, prev token.Token) string {
+	switch {
+	case tok == token.COMMENT:
+		return "c"
+	case tok == token.PACKAGE || tok == token.IMPORT:
+		return "kn"
+	case tok == token.FUNC || tok == token.VAR || tok == token.CONST || tok == token.TYPE:
+		return "kd"
+	case tok == token.MAP || tok == token.CHAN || tok == token.STRUCT || tok == token.INTERFACE:
+		return "kt"
+	case tok.IsKeyword():
+		return "k"
+	case tok == token.IDENT:
+		switch {
+		case prev == token.FUNC:
+			return "nf"
+		case goTypes[lit]:
+			return "kt"
+		case goConstants[lit]:
+			return "kc"
+		case goBuiltins[lit]:
+			return "nb"
+		}
+		return "n"
+	case tok == token.INT:
+		return "mi"
+	case tok == token.FLOAT || tok == token.IMAG:
+		return "mf"
+	case tok == token.CHAR:
+		return "sc"
+	case tok == token.STRING:
+		return "s"
+	case tok == token.ILLEGAL:
+		return "err"
+	case tok.IsOperator():
+		switch tok {
+		case token.LPAREN, token.RPAREN, token.LBRACK, token.RBRACK, token.LBRACE,
+			token.RBRACE, token.COMMA, token.PERIOD, token.SEMICOLON, token.COLON:
+			return "p"
+		}
+		return "o"
+	}
+	return ""
+}
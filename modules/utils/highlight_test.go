@@ -0,0 +1,71 @@
+package utils
+
+import (
+	"bytes"
+	"go/token"
+	"strings"
+	"testing"
+)
+
+func highlightGoString(src string) string {
+	var out bytes.Buffer
+	highlightGo(&out, []byte(src))
+	return out.String()
+}
+
+func TestHighlightGo(t *testing.T) {
+	ThrowFail(t, AssertIs(highlightGoString("if x { return nil }"),
+		`<span class="k">if</span> <span class="n">x</span> <span class="p">{</span> `+
+			`<span class="k">return</span> <span class="kc">nil</span> <span class="p">}</span>`))
+
+	// the source is escaped and illegal tokens are errors
+	ThrowFail(t, AssertIs(highlightGoString(`s := "<b>" @`),
+		`<span class="n">s</span> <span class="o">:=</span> <span class="s">&#34;&lt;b&gt;&#34;</span> <span class="err">@</span>`))
+
+	// carriage returns of raw strings and comments are kept in the token
+	ThrowFail(t, AssertIs(highlightGoString("x := `a\r\nb`\nfunc f"),
+		"<span class=\"n\">x</span> <span class=\"o\">:=</span> <span class=\"s\">`a\r\nb`</span>\n"+
+			`<span class="kd">func</span> <span class="nf">f</span>`))
+	ThrowFail(t, AssertIs(highlightGoString("a /*/ b\r\n */ c"),
+		"<span class=\"n\">a</span> <span class=\"c\">/*/ b\r\n */</span> <span class=\"n\">c</span>"))
+	ThrowFail(t, AssertIs(highlightGoString("//a\rb\nx"), "<span class=\"c\">//a\rb</span>\n<span class=\"n\">x</span>"))
+
+	// unterminated comments and raw strings run to the end
+	ThrowFail(t, AssertIs(highlightGoString("/* open\r\ncomment"), "<span class=\"c\">/* open\r\ncomment</span>"))
+	ThrowFail(t, AssertIs(highlightGoString("`open\r\nraw"), "<span class=\"s\">`open\r\nraw</span>"))
+}
+
+func TestGoTokenEnd(t *testing.T) {
+	src := []byte("x := `a\r\nb` + y")
+	ThrowFail(t, AssertIs(goTokenEnd(src, 0, token.IDENT, "x"), 1))
+	ThrowFail(t, AssertIs(goTokenEnd(src, 2, token.DEFINE, ""), 4))
+	ThrowFail(t, AssertIs(goTokenEnd(src, 5, token.STRING, "`a\nb`"), 11))
+
+	src = []byte("/* a\r\n")
+	ThrowFail(t, AssertIs(goTokenEnd(src, 0, token.COMMENT, "/* a\n"), len(src)))
+}
+
+func TestHighlightClasses(t *testing.T) {
+	classes := exactClasses(highlightClasses())
+
+	// all classes of the highlighted code are allowed by the policy
+	for _, class := range goTokenClasses {
+		ThrowFail(t, AssertIs(classes.MatchString(class), true))
+	}
+	src := "package main\nimport \"fmt\"\nfunc main() { var a map[int]string; fmt.Println(len(a), 1, 2.5, 'c', true) } // c @"
+	for _, m := range strings.Split(highlightGoString(src), `class="`)[1:] {
+		ThrowFail(t, AssertIs(classes.MatchString(m[:strings.Index(m, `"`)]), true))
+	}
+	var out bytes.Buffer
+	ThrowFailNow(t, AssertIs(highlightCode(&out, []byte("def f(x):\n    return 1 # c"), "python"), true))
+	for _, m := range strings.Split(out.String(), `class="`)[1:] {
+		ThrowFail(t, AssertIs(classes.MatchString(m[:strings.Index(m, `"`)]), true))
+	}
+
+	ThrowFail(t, AssertIs(classes.MatchString("chroma"), false))
+	ThrowFail(t, AssertIs(classes.MatchString("k n"), false))
+	ThrowFail(t, AssertIs(classes.MatchString("abcd"), false))
+
+	body := RenderMarkdown("<span class=\"k\">k</span><span class=\"abc\">e</span><span class=\"emoji\">:)</span>")
+	ThrowFail(t, AssertIs(body, "<p><span class=\"k\">k</span><span>e</span><span class=\"emoji\">:)</span></p>\n"))
+}
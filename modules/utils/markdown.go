@@ -15,22 +15,129 @@
 package utils
 
 import (
+	"bytes"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/microcosm-cc/bluemonday"
 	"github.com/russross/blackfriday"
+	"golang.org/x/net/html"
 )
 
+// ids of the html of users, only the anchors of headings are kept
+var markdownHeadingId = regexp.MustCompile(`^md-[\p{L}\p{N}\-_]+$`)
+
+var markdownHeadings = map[string]bool{
+	"h1": true, "h2": true, "h3": true, "h4": true, "h5": true, "h6": true,
+}
+
+// policy of the html allowed in rendered markdown, on top of the user generated content policy
+var markdownPolicy = newMarkdownPolicy()
+
+func newMarkdownPolicy() *bluemonday.Policy {
+	p := bluemonday.UGCPolicy()
+	p.AllowElements("details", "summary", "kbd", "sup", "sub", "ins", "del", "mark", "abbr")
+	p.AllowAttrs("open").Matching(regexp.MustCompile(`^(|open)$`)).OnElements("details")
+	p.AllowAttrs("title").OnElements("abbr")
+	// anchors of headings
+	p.AllowAttrs("id").Matching(markdownHeadingId).OnElements("h1", "h2", "h3", "h4", "h5", "h6")
+	// language of fenced code and the classes of highlighted tokens
+	p.AllowAttrs("class").Matching(regexp.MustCompile(`^language-[\w\-+#.]+$`)).OnElements("code")
+	p.AllowAttrs("class").Matching(regexp.MustCompile(`^highlight$`)).OnElements("pre")
+	p.AllowAttrs("class").Matching(exactClasses(append(highlightClasses(), "emoji"))).OnElements("span")
+	p.AllowAttrs("class").Matching(regexp.MustCompile(`^gofmt gofmt-(ok|diff)$`)).OnElements("div")
+	// links to the wiki pages and posts
+	p.AllowAttrs("class").Matching(regexp.MustCompile(`^(wiki-link|post-link)$`)).OnElements("a")
+	p.AllowAttrs("align").Matching(regexp.MustCompile(`^(left|center|right)$`)).OnElements("td", "th")
+	// MathML of the rendered math
+	p.AllowNoAttrs().OnElements("math", "semantics", "annotation", "mrow", "mi", "mn", "mo", "mtext", "mspace",
+		"msub", "msup", "msubsup", "munder", "mover", "munderover", "mfrac", "msqrt", "mroot",
+		"mtable", "mtr", "mtd")
+	p.AllowAttrs("xmlns").Matching(regexp.MustCompile(`^http://www\.w3\.org/1998/Math/MathML$`)).OnElements("math")
+	p.AllowAttrs("display").Matching(regexp.MustCompile(`^(inline|block)$`)).OnElements("math")
+	p.AllowAttrs("encoding").Matching(regexp.MustCompile(`^application/x-tex$`)).OnElements("annotation")
+	p.AllowAttrs("mathvariant").Matching(regexp.MustCompile(`^(normal|bold|italic|double-struck|script|sans-serif|monospace|fraktur)$`)).OnElements("mi")
+	p.AllowAttrs("fence", "stretchy", "largeop").Matching(regexp.MustCompile(`^(true|false)$`)).OnElements("mo")
+	p.AllowAttrs("accent").Matching(regexp.MustCompile(`^(true|false)$`)).OnElements("mover")
+	p.AllowAttrs("accentunder").Matching(regexp.MustCompile(`^(true|false)$`)).OnElements("munder")
+	p.AllowAttrs("width").Matching(regexp.MustCompile(`^[0-9.]+em$`)).OnElements("mspace")
+	p.AllowAttrs("linethickness").Matching(regexp.MustCompile(`^0$`)).OnElements("mfrac")
+	p.AllowAttrs("columnalign").Matching(regexp.MustCompile(`^(left|center|right)$`)).OnElements("mtable")
+	p.RequireNoFollowOnLinks(true)
+	return p
+}
+
+// exactClasses matches a class attribute of one of the classes
+func exactClasses(classes []string) *regexp.Regexp {
+	seen := make(map[string]bool)
+	var quoted []string
+	for _, class := range classes {
+		if !seen[class] {
+			seen[class] = true
+			quoted = append(quoted, regexp.QuoteMeta(class))
+		}
+	}
+	sort.Strings(quoted)
+	return regexp.MustCompile(`^(` + strings.Join(quoted, "|") + `)$`)
+}
+
+// tidyMarkdownHtml fixes the attributes of sanitized html which the policy
+// can't express: the sanitizer allows any id on all elements and only
+// knows nofollow, so ids other than the anchors of headings are dropped and
+// the rel of anchors marks them as user generated content.
+func tidyMarkdownHtml(body []byte) []byte {
+	var out bytes.Buffer
+	z := html.NewTokenizer(bytes.NewReader(body))
+	for {
+		tt := z.Next()
+		if tt == html.ErrorToken {
+			return out.Bytes()
+		}
+		if tt != html.StartTagToken && tt != html.SelfClosingTagToken {
+			out.Write(z.Raw())
+			continue
+		}
+
+		raw := append([]byte(nil), z.Raw()...)
+		tok := z.Token()
+		changed := false
+		attrs := tok.Attr[:0]
+		for _, attr := range tok.Attr {
+			switch {
+			case attr.Key == "id" && !(markdownHeadings[tok.Data] && markdownHeadingId.MatchString(attr.Val)):
+				changed = true
+				continue
+			case attr.Key == "rel" && attr.Val == "nofollow" && tok.Data == "a":
+				attr.Val = "nofollow ugc"
+				changed = true
+			}
+			attrs = append(attrs, attr)
+		}
+		if !changed {
+			out.Write(raw)
+			continue
+		}
+		tok.Attr = attrs
+		out.WriteString(tok.String())
+	}
+}
+
+// RenderMarkdown renders markdown to a html fragment, the registered rules
+// are applied to the text and the html is sanitized by the whitelist policy.
+// Math in $inline$ and $$block$$ is rendered to MathML.
 func RenderMarkdown(mdStr string) string {
 	htmlFlags := 0
 	htmlFlags |= blackfriday.HTML_USE_XHTML
 	// htmlFlags |= blackfriday.HTML_USE_SMARTYPANTS
 	// htmlFlags |= blackfriday.HTML_SMARTYPANTS_FRACTIONS
 	// htmlFlags |= blackfriday.HTML_SMARTYPANTS_LATEX_DASHES
-	htmlFlags |= blackfriday.HTML_SKIP_HTML
 	htmlFlags |= blackfriday.HTML_SKIP_STYLE
 	// htmlFlags |= blackfriday.HTML_SKIP_SCRIPT
 	// htmlFlags |= blackfriday.HTML_GITHUB_BLOCKCODE
-	htmlFlags |= blackfriday.HTML_OMIT_CONTENTS
-	htmlFlags |= blackfriday.HTML_COMPLETE_PAGE
-	renderer := blackfriday.HtmlRenderer(htmlFlags, "", "")
+	renderer := &highlightRenderer{blackfriday.HtmlRendererWithParameters(htmlFlags, "", "", blackfriday.HtmlRendererParameters{
+		HeaderIDPrefix: "md-",
+	})}
 
 	// set up the parser
 	extensions := 0
@@ -42,8 +149,14 @@ func RenderMarkdown(mdStr string) string {
 	extensions |= blackfriday.EXTENSION_HARD_LINE_BREAK
 	extensions |= blackfriday.EXTENSION_SPACE_HEADERS
 	extensions |= blackfriday.EXTENSION_NO_EMPTY_LINE_BEFORE_BLOCK
+	extensions |= blackfriday.EXTENSION_AUTO_HEADER_IDS
+
+	// math is rendered to MathML aside, the markdown parser doesn't know it
+	mdStr, maths := extractMath(mdStr)
 
 	body := blackfriday.Markdown([]byte(mdStr), renderer, extensions)
+	body = applyMarkdownRules(body)
+	body = []byte(restoreMath(string(body), maths))
 
-	return string(body)
+	return string(tidyMarkdownHtml(markdownPolicy.SanitizeBytes(body)))
 }
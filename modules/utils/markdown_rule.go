@@ -0,0 +1,187 @@
+package utils
+
+import (
+	"bytes"
+	"html/template"
+	"regexp"
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/net/html"
+)
+
+// MarkdownRule is an inline rule of rendered markdown. Pattern is matched
+// on the text outside of links and code, at word boundaries, and Render
+// returns the html of the match or false to keep the text as it is.
+// Rules with InCode are matched on the text of inline code instead.
+// The html is sanitized together with the rendered markdown.
+type MarkdownRule struct {
+	Name    string
+	Pattern *regexp.Regexp
+	Render  func(match []string) (string, bool)
+	InCode  bool
+}
+
+var (
+	markdownRules   []*MarkdownRule
+	markdownRulesMu sync.RWMutex
+)
+
+// RegisterMarkdownRule appends the rule to the markdown extensions, rules
+// are applied in the order they were registered and a rule with the same
+// name replaces the old one.
+func RegisterMarkdownRule(rule *MarkdownRule) {
+	markdownRulesMu.Lock()
+	defer markdownRulesMu.Unlock()
+	for i, r := range markdownRules {
+		if r.Name == rule.Name {
+			markdownRules[i] = rule
+			return
+		}
+	}
+	markdownRules = append(markdownRules, rule)
+}
+
+func getMarkdownRules() []*MarkdownRule {
+	markdownRulesMu.RLock()
+	defer markdownRulesMu.RUnlock()
+	return markdownRules
+}
+
+// elements whose text is kept as it is, but the text of inline code is
+// for the rules in code
+var markdownRuleSkips = map[string]bool{
+	"a": true, "code": true, "pre": true, "kbd": true, "script": true, "style": true,
+}
+
+// applyMarkdownRules applies the rules to the text nodes of rendered markdown.
+func applyMarkdownRules(body []byte) []byte {
+	var rules, codeRules []*MarkdownRule
+	for _, rule := range getMarkdownRules() {
+		if rule.InCode {
+			codeRules = append(codeRules, rule)
+		} else {
+			rules = append(rules, rule)
+		}
+	}
+	if len(rules) == 0 && len(codeRules) == 0 {
+		return body
+	}
+
+	var out bytes.Buffer
+	skips := make(map[string]int)
+	z := html.NewTokenizer(bytes.NewReader(body))
+	for {
+		tt := z.Next()
+		switch tt {
+		case html.ErrorToken:
+			return out.Bytes()
+		case html.StartTagToken, html.EndTagToken:
+			raw := append([]byte(nil), z.Raw()...)
+			name, _ := z.TagName()
+			if tag := string(name); markdownRuleSkips[tag] {
+				if tt == html.StartTagToken {
+					skips[tag]++
+				} else if skips[tag] > 0 {
+					skips[tag]--
+				}
+			}
+			out.Write(raw)
+		case html.TextToken:
+			skip := false
+			for tag, n := range skips {
+				if n > 0 && tag != "code" {
+					skip = true
+					break
+				}
+			}
+			switch {
+			case skip:
+				out.Write(z.Raw())
+			case skips["code"] > 0:
+				out.WriteString(renderMarkdownRules(codeRules, string(z.Text())))
+			default:
+				out.WriteString(renderMarkdownRules(rules, string(z.Text())))
+			}
+		default:
+			out.Write(z.Raw())
+		}
+	}
+}
+
+// part of text, html parts are the output of rules
+type ruleSegment struct {
+	text   string
+	isHtml bool
+}
+
+// renderMarkdownRules applies the rules one by one to the text which is
+// not rendered by the former rules, and returns the escaped html.
+func renderMarkdownRules(rules []*MarkdownRule, text string) string {
+	segments := []ruleSegment{{text: text}}
+	for _, rule := range rules {
+		var next []ruleSegment
+		for _, seg := range segments {
+			if seg.isHtml {
+				next = append(next, seg)
+				continue
+			}
+			next = append(next, applyMarkdownRule(rule, seg.text)...)
+		}
+		segments = next
+	}
+
+	var out bytes.Buffer
+	for _, seg := range segments {
+		if seg.isHtml {
+			out.WriteString(seg.text)
+		} else {
+			out.WriteString(template.HTMLEscapeString(seg.text))
+		}
+	}
+	return out.String()
+}
+
+func applyMarkdownRule(rule *MarkdownRule, text string) []ruleSegment {
+	var segments []ruleSegment
+	var last int
+	for _, loc := range rule.Pattern.FindAllStringSubmatchIndex(text, -1) {
+		start, end := loc[0], loc[1]
+		if start == end || !isRuleBoundary(text, start) {
+			continue
+		}
+
+		match := make([]string, len(loc)/2)
+		for i := range match {
+			if loc[2*i] >= 0 {
+				match[i] = text[loc[2*i]:loc[2*i+1]]
+			}
+		}
+		rendered, ok := rule.Render(match)
+		if !ok {
+			continue
+		}
+
+		if start > last {
+			segments = append(segments, ruleSegment{text: text[last:start]})
+		}
+		segments = append(segments, ruleSegment{text: rendered, isHtml: true})
+		last = end
+	}
+	if last < len(text) {
+		segments = append(segments, ruleSegment{text: text[last:]})
+	}
+	return segments
+}
+
+// matches start at the beginning of text or after a rune which is not part
+// of a word, path or email
+func isRuleBoundary(text string, start int) bool {
+	if start == 0 {
+		return true
+	}
+	r, _ := utf8.DecodeLastRuneInString(text[:start])
+	return !unicode.IsLetter(r) && !unicode.IsDigit(r) && !strings.ContainsRune("_-./@#&", r)
+}
@@ -0,0 +1,70 @@
+package utils
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/missdeer/wego/setting"
+)
+
+var testTicketRule = &MarkdownRule{
+	Name:    "ticket",
+	Pattern: regexp.MustCompile(`T(\d+)`),
+	Render: func(m []string) (string, bool) {
+		if m[1] == "0" {
+			return "", false
+		}
+		return `<a href="/t/` + m[1] + `">` + m[0] + `</a>`, true
+	},
+}
+
+func TestIsRuleBoundary(t *testing.T) {
+	ThrowFail(t, AssertIs(isRuleBoundary("T1", 0), true))
+	ThrowFail(t, AssertIs(isRuleBoundary("see T1", 4), true))
+	ThrowFail(t, AssertIs(isRuleBoundary("(T1)", 1), true))
+	ThrowFail(t, AssertIs(isRuleBoundary("中T1", len("中")), false))
+	for _, text := range []string{"aT1", "1T1", "_T1", "-T1", ".T1", "/T1", "@T1", "#T1", "&T1"} {
+		ThrowFail(t, AssertIs(isRuleBoundary(text, 1), false))
+	}
+}
+
+func TestRenderMarkdownRules(t *testing.T) {
+	rules := []*MarkdownRule{testTicketRule}
+	ThrowFail(t, AssertIs(renderMarkdownRules(rules, "T1 and T2."), `<a href="/t/1">T1</a> and <a href="/t/2">T2</a>.`))
+
+	// the text around the matches is escaped, matches inside words are kept
+	ThrowFail(t, AssertIs(renderMarkdownRules(rules, "<T3> aT4 T0"), `&lt;<a href="/t/3">T3</a>&gt; aT4 T0`))
+
+	// the output of former rules is not matched again
+	other := &MarkdownRule{
+		Name:    "bold-ticket",
+		Pattern: regexp.MustCompile(`/t/(\d+)`),
+		Render: func(m []string) (string, bool) {
+			return "<b>" + m[0] + "</b>", true
+		},
+	}
+	ThrowFail(t, AssertIs(renderMarkdownRules([]*MarkdownRule{testTicketRule, other}, "T5 /t/6"),
+		`<a href="/t/5">T5</a> <b>/t/6</b>`))
+
+	ThrowFail(t, AssertIs(renderMarkdownRules(nil, "a & b"), "a &amp; b"))
+}
+
+func TestApplyMarkdownRules(t *testing.T) {
+	// text is matched, but not the text of links and code blocks
+	body := applyMarkdownRules([]byte(`<p>:smile: <a href="/a">:smile:</a></p><pre><code>:smile:</code></pre>`))
+	ThrowFail(t, AssertIs(string(body),
+		`<p><span class="emoji" title=":smile:">`+"\U0001F604"+`</span> <a href="/a">:smile:</a></p><pre><code>:smile:</code></pre>`))
+
+	// inline code is for the rules in code only
+	setting.GoDocURL = "https://pkg.go.dev/{path}"
+	body = applyMarkdownRules([]byte(`<p><code>:smile:</code> <code>"net/http"</code></p>`))
+	ThrowFail(t, AssertIs(string(body), `<p><code>:smile:</code> <code><a href="https://pkg.go.dev/net/http">&#34;net/http&#34;</a></code></p>`))
+
+	// entities and attributes are kept
+	body = applyMarkdownRules([]byte(`<p title="x">a &amp; b &lt;c&gt;<br/>d</p>`))
+	ThrowFail(t, AssertIs(string(body), `<p title="x">a &amp; b &lt;c&gt;<br/>d</p>`))
+
+	// unclosed skipped elements don't leak
+	body = applyMarkdownRules([]byte(`<p></a>:smile:</p>`))
+	ThrowFail(t, AssertIs(string(body), `<p></a><span class="emoji" title=":smile:">`+"\U0001F604"+`</span></p>`))
+}
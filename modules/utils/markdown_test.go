@@ -0,0 +1,80 @@
+package utils
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRenderMarkdownSanitize(t *testing.T) {
+	// raw script is dropped with its content
+	body := RenderMarkdown("hello <script>alert(1)</script> world")
+	ThrowFail(t, AssertIs(strings.Contains(body, "<script"), false))
+	ThrowFail(t, AssertIs(strings.Contains(body, "alert(1)"), false))
+	ThrowFail(t, AssertIs(strings.Contains(body, "world"), true))
+
+	// javascript links lose their href
+	body = RenderMarkdown("[click](javascript:alert(1)) <a href=\"javascript:alert(1)\">raw</a>")
+	ThrowFail(t, AssertIs(strings.Contains(body, "javascript:"), false))
+
+	// event handlers and style are dropped
+	body = RenderMarkdown("<img src=\"/a.png\" onerror=\"alert(1)\"> <b onclick=\"alert(1)\" style=\"color:red\">b</b>")
+	ThrowFail(t, AssertIs(strings.Contains(body, "onerror"), false))
+	ThrowFail(t, AssertIs(strings.Contains(body, "onclick"), false))
+	ThrowFail(t, AssertIs(strings.Contains(body, "style="), false))
+	ThrowFail(t, AssertIs(strings.Contains(body, `src="/a.png"`), true))
+
+	// the extra elements are kept
+	body = RenderMarkdown("<details open><summary>more</summary><kbd>Ctrl</kbd> <abbr title=\"HyperText\">HTML</abbr></details>")
+	ThrowFail(t, AssertIs(strings.Contains(body, "<details open"), true))
+	ThrowFail(t, AssertIs(strings.Contains(body, "<summary>more</summary>"), true))
+	ThrowFail(t, AssertIs(strings.Contains(body, "<kbd>Ctrl</kbd>"), true))
+	ThrowFail(t, AssertIs(strings.Contains(body, `<abbr title="HyperText">`), true))
+}
+
+func TestRenderMarkdownClassesAndIds(t *testing.T) {
+	// anchors of headings are prefixed
+	body := RenderMarkdown("# Hello World")
+	ThrowFail(t, AssertIs(strings.Contains(body, `<h1 id="md-hello-world">`), true))
+
+	// ids and classes of users are dropped
+	body = RenderMarkdown("<h2 id=\"login\">x</h2> <div class=\"modal\">y</div> <span class=\"btn-primary\">z</span>")
+	ThrowFail(t, AssertIs(strings.Contains(body, "login"), false))
+	ThrowFail(t, AssertIs(strings.Contains(body, "modal"), false))
+	ThrowFail(t, AssertIs(strings.Contains(body, "btn-primary"), false))
+
+	body = RenderMarkdown("<h2 id=\"md-intro\">x</h2> <p id=\"md-intro\">y</p>")
+	ThrowFail(t, AssertIs(strings.Count(body, `id="md-intro"`), 1))
+
+	// classes of the links of wiki pages and of the code
+	body = RenderMarkdown("<a class=\"wiki-link\" href=\"/wiki/a\">a</a> <a class=\"wiki-missing\" href=\"/wiki/c\">c</a> <a class=\"btn\" href=\"/b\">b</a>")
+	ThrowFail(t, AssertIs(strings.Contains(body, `class="wiki-link"`), true))
+	ThrowFail(t, AssertIs(strings.Contains(body, `wiki-missing`), false))
+	ThrowFail(t, AssertIs(strings.Contains(body, `class="btn"`), false))
+
+	body = RenderMarkdown("<pre class=\"evil\"><code class=\"language-go\">x</code></pre>")
+	ThrowFail(t, AssertIs(strings.Contains(body, `class="evil"`), false))
+	ThrowFail(t, AssertIs(strings.Contains(body, `class="language-go"`), true))
+}
+
+func TestRenderMarkdownLinkRel(t *testing.T) {
+	body := RenderMarkdown("[site](http://example.com) <a href=\"/x\" rel=\"nofollow\">y</a>")
+	ThrowFail(t, AssertIs(strings.Count(body, `rel="nofollow ugc"`), 2))
+
+	// text which looks like the attribute is kept as it is
+	body = RenderMarkdown("`rel=\"nofollow\"` and rel=\"nofollow\"")
+	ThrowFail(t, AssertIs(strings.Contains(body, "ugc"), false))
+
+	// links without href have no rel
+	body = RenderMarkdown("<a name=\"top\">top</a>")
+	ThrowFail(t, AssertIs(strings.Contains(body, "rel="), false))
+}
+
+func TestTidyMarkdownHtml(t *testing.T) {
+	ThrowFail(t, AssertIs(string(tidyMarkdownHtml([]byte(`<p><a href="/a" rel="nofollow">a &amp; b</a></p>`))),
+		`<p><a href="/a" rel="nofollow ugc">a &amp; b</a></p>`))
+	ThrowFail(t, AssertIs(string(tidyMarkdownHtml([]byte(`<area href="/a" rel="nofollow"/>`))), `<area href="/a" rel="nofollow"/>`))
+	ThrowFail(t, AssertIs(string(tidyMarkdownHtml([]byte(`rel=&#34;nofollow&#34;`))), `rel=&#34;nofollow&#34;`))
+
+	ThrowFail(t, AssertIs(string(tidyMarkdownHtml([]byte(`<h3 id="md-a">a</h3><p id="md-b">b</p><img id="x" src="/c.png"/>`))),
+		`<h3 id="md-a">a</h3><p>b</p><img src="/c.png"/>`))
+}
@@ -0,0 +1,739 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"html/template"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+var errMath = errors.New("malformed math")
+
+// limits of math expressions, longer or deeper ones are kept as text
+const (
+	mathMaxLength = 2000
+	mathMaxDepth  = 32
+)
+
+var mathGreeks = map[string]string{
+	"alpha": "α", "beta": "β", "gamma": "γ", "delta": "δ", "epsilon": "ϵ", "varepsilon": "ε",
+	"zeta": "ζ", "eta": "η", "theta": "θ", "vartheta": "ϑ", "iota": "ι", "kappa": "κ",
+	"lambda": "λ", "mu": "μ", "nu": "ν", "xi": "ξ", "pi": "π", "varpi": "ϖ", "rho": "ρ",
+	"varrho": "ϱ", "sigma": "σ", "varsigma": "ς", "tau": "τ", "upsilon": "υ", "phi": "ϕ",
+	"varphi": "φ", "chi": "χ", "psi": "ψ", "omega": "ω",
+	"Gamma": "Γ", "Delta": "Δ", "Theta": "Θ", "Lambda": "Λ", "Xi": "Ξ", "Pi": "Π",
+	"Sigma": "Σ", "Upsilon": "Υ", "Phi": "Φ", "Psi": "Ψ", "Omega": "Ω",
+}
+
+// symbols rendered as identifiers
+var mathIdentifiers = map[string]string{
+	"infty": "∞", "partial": "∂", "nabla": "∇", "emptyset": "∅", "hbar": "ℏ", "ell": "ℓ",
+	"aleph": "ℵ", "Re": "ℜ", "Im": "ℑ",
+}
+
+var mathOperators = map[string]string{
+	"times": "×", "cdot": "⋅", "pm": "±", "mp": "∓", "div": "÷", "ast": "∗", "star": "⋆",
+	"circ": "∘", "bullet": "∙", "oplus": "⊕", "otimes": "⊗",
+	"leq": "≤", "le": "≤", "geq": "≥", "ge": "≥", "neq": "≠", "ne": "≠", "ll": "≪", "gg": "≫",
+	"approx": "≈", "equiv": "≡", "sim": "∼", "simeq": "≃", "cong": "≅", "propto": "∝",
+	"to": "→", "rightarrow": "→", "leftarrow": "←", "leftrightarrow": "↔", "mapsto": "↦",
+	"Rightarrow": "⇒", "Leftarrow": "⇐", "Leftrightarrow": "⇔", "implies": "⟹", "iff": "⟺",
+	"in": "∈", "notin": "∉", "ni": "∋", "subset": "⊂", "supset": "⊃", "subseteq": "⊆",
+	"supseteq": "⊇", "cup": "∪", "cap": "∩", "setminus": "∖",
+	"forall": "∀", "exists": "∃", "neg": "¬", "lnot": "¬", "land": "∧", "wedge": "∧",
+	"lor": "∨", "vee": "∨", "mid": "∣", "parallel": "∥", "perp": "⊥",
+	"ldots": "…", "cdots": "⋯", "vdots": "⋮", "ddots": "⋱", "dots": "…",
+	"lfloor": "⌊", "rfloor": "⌋", "lceil": "⌈", "rceil": "⌉", "langle": "⟨", "rangle": "⟩",
+	"{": "{", "}": "}", "|": "‖", "vert": "|", "Vert": "‖",
+}
+
+// big operators, the limits of the ones in limitOperators are under and over them in display
+var mathBigOperators = map[string]string{
+	"sum": "∑", "prod": "∏", "coprod": "∐", "int": "∫", "iint": "∬", "iiint": "∭", "oint": "∮",
+	"bigcup": "⋃", "bigcap": "⋂", "bigoplus": "⨁", "bigotimes": "⨂",
+}
+
+var mathFunctions = map[string]bool{
+	"sin": true, "cos": true, "tan": true, "cot": true, "sec": true, "csc": true,
+	"arcsin": true, "arccos": true, "arctan": true, "sinh": true, "cosh": true, "tanh": true,
+	"log": true, "ln": true, "lg": true, "exp": true, "det": true, "dim": true, "ker": true,
+	"gcd": true, "deg": true, "arg": true, "lim": true, "max": true, "min": true, "sup": true,
+	"inf": true, "Pr": true,
+}
+
+var mathLimitOperators = map[string]bool{
+	"sum": true, "prod": true, "coprod": true, "bigcup": true, "bigcap": true, "bigoplus": true,
+	"bigotimes": true, "lim": true, "max": true, "min": true, "sup": true, "inf": true,
+}
+
+var mathAccents = map[string]string{
+	"hat": "^", "widehat": "^", "bar": "¯", "overline": "¯", "vec": "→", "tilde": "~",
+	"widetilde": "~", "dot": "˙", "ddot": "¨", "overrightarrow": "→",
+}
+
+var mathVariants = map[string]string{
+	"mathbb": "double-struck", "mathbf": "bold", "mathrm": "normal", "mathit": "italic",
+	"mathcal": "script", "mathsf": "sans-serif", "mathtt": "monospace", "mathfrak": "fraktur",
+}
+
+var mathSpaces = map[string]string{
+	",": "0.167em", ":": "0.222em", ";": "0.278em", " ": "0.25em", "quad": "1em", "qquad": "2em",
+}
+
+// matrix environments and their fences
+var mathMatrices = map[string][2]string{
+	"matrix": {"", ""}, "pmatrix": {"(", ")"}, "bmatrix": {"[", "]"}, "Bmatrix": {"{", "}"},
+	"vmatrix": {"|", "|"}, "Vmatrix": {"‖", "‖"}, "cases": {"{", ""}, "aligned": {"", ""},
+	"array": {"", ""},
+}
+
+// parser of the subset of LaTeX math used in posts
+type mathParser struct {
+	src     []rune
+	pos     int
+	depth   int
+	display bool
+}
+
+// RenderMath converts LaTeX math to MathML, an error is returned for
+// malformed or unsupported input.
+func RenderMath(src string, display bool) (string, error) {
+	if len([]rune(src)) > mathMaxLength {
+		return "", errMath
+	}
+	p := &mathParser{src: []rune(src), display: display}
+	body, err := p.parseExpr()
+	if err != nil {
+		return "", err
+	}
+	if !p.eof() {
+		return "", errMath
+	}
+
+	mode := "inline"
+	if display {
+		mode = "block"
+	}
+	return fmt.Sprintf(`<math xmlns="http://www.w3.org/1998/Math/MathML" display="%s"><semantics><mrow>%s</mrow>`+
+		`<annotation encoding="application/x-tex">%s</annotation></semantics></math>`,
+		mode, body, template.HTMLEscapeString(src)), nil
+}
+
+func (p *mathParser) eof() bool {
+	return p.pos >= len(p.src)
+}
+
+func (p *mathParser) peek() rune {
+	if p.eof() {
+		return 0
+	}
+	return p.src[p.pos]
+}
+
+func (p *mathParser) skipSpace() {
+	for !p.eof() && unicode.IsSpace(p.src[p.pos]) {
+		p.pos++
+	}
+}
+
+// whether the next command is name, without consuming it
+func (p *mathParser) atCommand(name string) bool {
+	s := `\` + name
+	if p.pos+len(s) > len(p.src) || string(p.src[p.pos:p.pos+len(s)]) != s {
+		return false
+	}
+	next := p.pos + len(s)
+	return next >= len(p.src) || !unicode.IsLetter(p.src[next]) || !unicode.IsLetter(rune(name[0]))
+}
+
+// the tokens which end an expression, they are consumed by the callers
+func (p *mathParser) atStop() bool {
+	switch p.peek() {
+	case '}', '&', ']':
+		return true
+	}
+	return p.atCommand(`\`) || p.atCommand("right") || p.atCommand("end")
+}
+
+// parseExpr parses atoms with their scripts until a stop token.
+func (p *mathParser) parseExpr() (string, error) {
+	p.depth++
+	defer func() { p.depth-- }()
+	if p.depth > mathMaxDepth {
+		return "", errMath
+	}
+
+	var out strings.Builder
+	for {
+		p.skipSpace()
+		if p.eof() || p.atStop() {
+			return out.String(), nil
+		}
+		node, err := p.parseScripted()
+		if err != nil {
+			return "", err
+		}
+		out.WriteString(node)
+	}
+}
+
+// parse an atom with its subscript and superscript
+func (p *mathParser) parseScripted() (string, error) {
+	var base string
+	var limits bool
+	if c := p.peek(); c == '^' || c == '_' {
+		base = "<mrow></mrow>"
+	} else {
+		var err error
+		if base, limits, err = p.parseAtom(false); err != nil {
+			return "", err
+		}
+	}
+
+	var sub, sup, primes string
+	for {
+		p.skipSpace()
+		c := p.peek()
+		if c != '^' && c != '_' && c != '\'' {
+			break
+		}
+		p.pos++
+		if c == '\'' {
+			primes += "<mo>′</mo>"
+			continue
+		}
+		arg, _, err := p.parseAtom(true)
+		if err != nil {
+			return "", err
+		}
+		if c == '^' {
+			if len(sup) > 0 {
+				return "", errMath
+			}
+			sup = arg
+		} else {
+			if len(sub) > 0 {
+				return "", errMath
+			}
+			sub = arg
+		}
+	}
+	if len(primes) > 0 {
+		if len(sup) > 0 || strings.Count(primes, "′") > 1 {
+			sup = "<mrow>" + primes + sup + "</mrow>"
+		} else {
+			sup = primes
+		}
+	}
+
+	under, over, both := "msub", "msup", "msubsup"
+	if limits && p.display {
+		under, over, both = "munder", "mover", "munderover"
+	}
+	switch {
+	case len(sub) > 0 && len(sup) > 0:
+		return fmt.Sprintf("<%s>%s%s%s</%s>", both, base, sub, sup, both), nil
+	case len(sub) > 0:
+		return fmt.Sprintf("<%s>%s%s</%s>", under, base, sub, under), nil
+	case len(sup) > 0:
+		return fmt.Sprintf("<%s>%s%s</%s>", over, base, sup, over), nil
+	}
+	return base, nil
+}
+
+// parse a group in braces as a row
+func (p *mathParser) parseGroup() (string, error) {
+	p.skipSpace()
+	if p.peek() != '{' {
+		return "", errMath
+	}
+	p.pos++
+	body, err := p.parseExpr()
+	if err != nil {
+		return "", err
+	}
+	if p.peek() != '}' {
+		return "", errMath
+	}
+	p.pos++
+	return "<mrow>" + body + "</mrow>", nil
+}
+
+// raw text of a group in braces
+func (p *mathParser) parseText() (string, error) {
+	p.skipSpace()
+	if p.peek() != '{' {
+		return "", errMath
+	}
+	start := p.pos + 1
+	for depth := 0; !p.eof(); p.pos++ {
+		switch p.src[p.pos] {
+		case '{':
+			depth++
+		case '}':
+			depth--
+			if depth == 0 {
+				text := string(p.src[start:p.pos])
+				p.pos++
+				return text, nil
+			}
+		}
+	}
+	return "", errMath
+}
+
+// parseAtom parses a symbol, a group or a command, the argument of
+// a script or a command is a single symbol if it isn't a group.
+// Whether the atom takes limits is returned as well.
+func (p *mathParser) parseAtom(arg bool) (string, bool, error) {
+	p.skipSpace()
+	if p.eof() {
+		return "", false, errMath
+	}
+	c := p.src[p.pos]
+	switch {
+	case c == '{':
+		node, err := p.parseGroup()
+		return node, false, err
+	case c == '\\':
+		return p.parseCommand()
+	case unicode.IsDigit(c):
+		start := p.pos
+		p.pos++
+		for !arg && !p.eof() && (unicode.IsDigit(p.peek()) ||
+			(p.peek() == '.' && p.pos+1 < len(p.src) && unicode.IsDigit(p.src[p.pos+1]))) {
+			p.pos++
+		}
+		return "<mn>" + string(p.src[start:p.pos]) + "</mn>", false, nil
+	case unicode.IsLetter(c):
+		p.pos++
+		return "<mi>" + template.HTMLEscapeString(string(c)) + "</mi>", false, nil
+	case strings.ContainsRune("+-=<>/*()[]|,.;:!?", c):
+		p.pos++
+		switch c {
+		case '-':
+			return "<mo>−</mo>", false, nil
+		case '*':
+			return "<mo>∗</mo>", false, nil
+		}
+		return "<mo>" + template.HTMLEscapeString(string(c)) + "</mo>", false, nil
+	}
+	return "", false, errMath
+}
+
+func (p *mathParser) parseCommand() (string, bool, error) {
+	p.pos++
+	if p.eof() {
+		return "", false, errMath
+	}
+	start := p.pos
+	for !p.eof() && unicode.IsLetter(p.peek()) {
+		p.pos++
+	}
+	if p.pos == start {
+		p.pos++
+	}
+	name := string(p.src[start:p.pos])
+
+	if s, ok := mathGreeks[name]; ok {
+		if unicode.IsUpper(rune(name[0])) {
+			return `<mi mathvariant="normal">` + s + "</mi>", false, nil
+		}
+		return "<mi>" + s + "</mi>", false, nil
+	}
+	if s, ok := mathIdentifiers[name]; ok {
+		return "<mi>" + s + "</mi>", false, nil
+	}
+	if s, ok := mathOperators[name]; ok {
+		return "<mo>" + template.HTMLEscapeString(s) + "</mo>", false, nil
+	}
+	if s, ok := mathBigOperators[name]; ok {
+		return `<mo largeop="true">` + s + "</mo>", mathLimitOperators[name], nil
+	}
+	if mathFunctions[name] {
+		return "<mi>" + name + "</mi>", mathLimitOperators[name], nil
+	}
+	if width, ok := mathSpaces[name]; ok {
+		return `<mspace width="` + width + `"></mspace>`, false, nil
+	}
+	if s, ok := mathAccents[name]; ok {
+		arg, _, err := p.parseAtom(true)
+		if err != nil {
+			return "", false, err
+		}
+		return `<mover accent="true">` + arg + `<mo stretchy="true">` + s + "</mo></mover>", false, nil
+	}
+	if variant, ok := mathVariants[name]; ok {
+		text, err := p.parseText()
+		if err != nil || strings.ContainsAny(text, `\{}`) {
+			return "", false, errMath
+		}
+		var out strings.Builder
+		for _, r := range strings.TrimSpace(text) {
+			if !unicode.IsSpace(r) {
+				fmt.Fprintf(&out, `<mi mathvariant="%s">%s</mi>`, variant, template.HTMLEscapeString(string(r)))
+			}
+		}
+		return "<mrow>" + out.String() + "</mrow>", false, nil
+	}
+
+	switch name {
+	case "!":
+		return "", false, nil
+	case "%", "$", "#", "&", "_":
+		return "<mo>" + template.HTMLEscapeString(name) + "</mo>", false, nil
+	case "frac", "dfrac", "tfrac", "binom":
+		num, _, err := p.parseAtom(true)
+		if err != nil {
+			return "", false, err
+		}
+		den, _, err := p.parseAtom(true)
+		if err != nil {
+			return "", false, err
+		}
+		if name == "binom" {
+			return `<mrow><mo>(</mo><mfrac linethickness="0">` + num + den + `</mfrac><mo>)</mo></mrow>`, false, nil
+		}
+		return "<mfrac>" + num + den + "</mfrac>", false, nil
+	case "sqrt":
+		p.skipSpace()
+		if p.peek() == '[' {
+			p.pos++
+			index, err := p.parseExpr()
+			if err != nil || p.peek() != ']' {
+				return "", false, errMath
+			}
+			p.pos++
+			arg, _, err := p.parseAtom(true)
+			if err != nil {
+				return "", false, err
+			}
+			return "<mroot>" + arg + "<mrow>" + index + "</mrow></mroot>", false, nil
+		}
+		arg, _, err := p.parseAtom(true)
+		if err != nil {
+			return "", false, err
+		}
+		return "<msqrt>" + arg + "</msqrt>", false, nil
+	case "underline":
+		arg, _, err := p.parseAtom(true)
+		if err != nil {
+			return "", false, err
+		}
+		return `<munder accentunder="true">` + arg + `<mo stretchy="true">_</mo></munder>`, false, nil
+	case "text", "textrm", "mbox", "textit", "textbf":
+		text, err := p.parseText()
+		if err != nil {
+			return "", false, err
+		}
+		return "<mtext>" + template.HTMLEscapeString(text) + "</mtext>", false, nil
+	case "operatorname":
+		text, err := p.parseText()
+		if err != nil || strings.ContainsAny(text, `\{}`) {
+			return "", false, errMath
+		}
+		return "<mi>" + template.HTMLEscapeString(strings.TrimSpace(text)) + "</mi>", false, nil
+	case "left":
+		open, err := p.parseDelimiter()
+		if err != nil {
+			return "", false, err
+		}
+		body, err := p.parseExpr()
+		if err != nil {
+			return "", false, err
+		}
+		if !p.atCommand("right") {
+			return "", false, errMath
+		}
+		p.pos += len(`\right`)
+		close, err := p.parseDelimiter()
+		if err != nil {
+			return "", false, err
+		}
+		return "<mrow>" + mathFence(open) + body + mathFence(close) + "</mrow>", false, nil
+	case "begin":
+		node, err := p.parseMatrix()
+		return node, false, err
+	}
+	return "", false, errMath
+}
+
+func mathFence(delim string) string {
+	if len(delim) == 0 {
+		return ""
+	}
+	return `<mo fence="true" stretchy="true">` + template.HTMLEscapeString(delim) + "</mo>"
+}
+
+// delimiter after \left and \right, an empty string for the invisible one
+func (p *mathParser) parseDelimiter() (string, error) {
+	p.skipSpace()
+	c := p.peek()
+	switch {
+	case c == '.':
+		p.pos++
+		return "", nil
+	case strings.ContainsRune("()[]|/", c):
+		p.pos++
+		return string(c), nil
+	case c == '\\':
+		p.pos++
+		start := p.pos
+		for !p.eof() && unicode.IsLetter(p.peek()) {
+			p.pos++
+		}
+		if p.pos == start && !p.eof() {
+			p.pos++
+		}
+		name := string(p.src[start:p.pos])
+		switch name {
+		case "{", "}", "|", "langle", "rangle", "lfloor", "rfloor", "lceil", "rceil", "vert", "Vert":
+			return mathOperators[name], nil
+		}
+	}
+	return "", errMath
+}
+
+// parse \begin{env} rows of cells \end{env} as a table
+func (p *mathParser) parseMatrix() (string, error) {
+	env, err := p.parseText()
+	if err != nil {
+		return "", err
+	}
+	fences, ok := mathMatrices[env]
+	if !ok {
+		return "", errMath
+	}
+	if env == "array" {
+		// column alignments are ignored
+		if _, err := p.parseText(); err != nil {
+			return "", err
+		}
+	}
+
+	var table, row strings.Builder
+	for {
+		cell, err := p.parseExpr()
+		if err != nil {
+			return "", err
+		}
+		row.WriteString("<mtd>" + cell + "</mtd>")
+		switch {
+		case p.peek() == '&':
+			p.pos++
+			continue
+		case p.atCommand(`\`):
+			p.pos += 2
+			table.WriteString("<mtr>" + row.String() + "</mtr>")
+			row.Reset()
+			continue
+		case p.atCommand("end"):
+			p.pos += len(`\end`)
+			if end, err := p.parseText(); err != nil || end != env {
+				return "", errMath
+			}
+			if row.Len() > len("<mtd></mtd>") || table.Len() == 0 {
+				table.WriteString("<mtr>" + row.String() + "</mtr>")
+			}
+		default:
+			return "", errMath
+		}
+		break
+	}
+
+	node := "<mtable>" + table.String() + "</mtable>"
+	if env == "cases" || env == "aligned" {
+		node = `<mtable columnalign="left">` + table.String() + "</mtable>"
+	}
+	return "<mrow>" + mathFence(fences[0]) + node + mathFence(fences[1]) + "</mrow>", nil
+}
+
+// rendered math of markdown, the key is in their placeholders
+type mathNodes struct {
+	key   string
+	nodes []string
+}
+
+// placeholder of rendered math in markdown, it survives markdown and
+// becomes no part of a heading id
+func (m *mathNodes) placeholder(i int) string {
+	return fmt.Sprintf("⟦%s%d⟧", m.key, i)
+}
+
+// key of the placeholders which is not in the markdown, so placeholders
+// typed by users are kept as text
+func mathKey(md string) string {
+	key := "wegomath"
+	for strings.Contains(md, "⟦"+key) {
+		key += "x"
+	}
+	return key
+}
+
+// extractMath replaces the math outside of code in markdown with
+// placeholders and returns the rendered MathML of them. Malformed math
+// is kept as text.
+func extractMath(md string) (string, *mathNodes) {
+	maths := &mathNodes{}
+	if !strings.Contains(md, "$") {
+		return md, maths
+	}
+
+	maths.key = mathKey(md)
+	var out, chunk strings.Builder
+	flush := func() {
+		out.WriteString(replaceMath(chunk.String(), maths))
+		chunk.Reset()
+	}
+
+	var fence string
+	prevBlank, inIndented := true, false
+	for _, line := range strings.SplitAfter(md, "\n") {
+		trimmed := strings.TrimLeft(line, " ")
+		if len(fence) > 0 {
+			out.WriteString(line)
+			if strings.HasPrefix(trimmed, fence) {
+				fence = ""
+			}
+			continue
+		}
+		if strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			flush()
+			fence = trimmed[:3]
+			out.WriteString(line)
+			continue
+		}
+
+		blank := len(strings.TrimSpace(line)) == 0
+		indented := strings.HasPrefix(line, "    ") || strings.HasPrefix(line, "\t")
+		if !blank && indented && (prevBlank || inIndented) {
+			// indented code block
+			flush()
+			out.WriteString(line)
+			inIndented, prevBlank = true, false
+			continue
+		}
+		if !blank {
+			inIndented = false
+		}
+		chunk.WriteString(line)
+		prevBlank = blank
+	}
+	flush()
+	return out.String(), maths
+}
+
+// replace the math of text outside of code spans
+func replaceMath(text string, maths *mathNodes) string {
+	var out strings.Builder
+	for i := 0; i < len(text); {
+		switch text[i] {
+		case '\\':
+			if i+1 < len(text) && text[i+1] == '$' {
+				out.WriteString("&#36;")
+				i += 2
+				continue
+			}
+		case '`':
+			n := 1
+			for i+n < len(text) && text[i+n] == '`' {
+				n++
+			}
+			run := text[i : i+n]
+			if end := strings.Index(text[i+n:], run); end >= 0 {
+				out.WriteString(text[i : i+n+end+n])
+				i += n + end + n
+			} else {
+				out.WriteString(run)
+				i += n
+			}
+			continue
+		case '$':
+			if node, n := renderMathAt(text[i:]); n > 0 {
+				out.WriteString(maths.placeholder(len(maths.nodes)))
+				maths.nodes = append(maths.nodes, node)
+				i += n
+				continue
+			}
+			if strings.HasPrefix(text[i:], "$$") {
+				out.WriteString("$$")
+				i += 2
+				continue
+			}
+		}
+		out.WriteByte(text[i])
+		i++
+	}
+	return out.String()
+}
+
+// render the math at the start of text, the length of source is returned
+// or zero if it isn't math
+func renderMathAt(text string) (string, int) {
+	if strings.HasPrefix(text, "$$") {
+		end := strings.Index(text[2:], "$$")
+		if end < 0 || len(strings.TrimSpace(text[2:2+end])) == 0 {
+			return "", 0
+		}
+		node, err := RenderMath(strings.TrimSpace(text[2:2+end]), true)
+		if err != nil {
+			return "", 0
+		}
+		return node, 2 + end + 2
+	}
+
+	// inline math doesn't start or end with space and isn't followed by a
+	// digit, so prices like $5 and $10 are not math
+	end := strings.IndexAny(text[1:], "$\n")
+	if end <= 0 || text[1+end] != '$' {
+		return "", 0
+	}
+	src := text[1 : 1+end]
+	if unicode.IsSpace(rune(src[0])) || unicode.IsSpace(rune(src[len(src)-1])) || src[len(src)-1] == '\\' {
+		return "", 0
+	}
+	if 2+end < len(text) && text[2+end] >= '0' && text[2+end] <= '9' {
+		return "", 0
+	}
+	node, err := RenderMath(src, false)
+	if err != nil {
+		return "", 0
+	}
+	return node, 1 + end + 1
+}
+
+// restoreMath puts the rendered math in the place of placeholders in the
+// text of html, placeholders in tags are left as they are. The MathML is
+// sanitized together with the html.
+func restoreMath(html string, maths *mathNodes) string {
+	if len(maths.nodes) == 0 {
+		return html
+	}
+
+	prefix := "⟦" + maths.key
+	var out strings.Builder
+	inTag := false
+	for i := 0; i < len(html); {
+		switch html[i] {
+		case '<':
+			inTag = true
+		case '>':
+			inTag = false
+		}
+		if !inTag && strings.HasPrefix(html[i:], prefix) {
+			if end := strings.Index(html[i:], "⟧"); end > 0 {
+				n, err := strconv.Atoi(html[i+len(prefix) : i+end])
+				if err == nil && n >= 0 && n < len(maths.nodes) && html[i:i+end+len("⟧")] == maths.placeholder(n) {
+					out.WriteString(maths.nodes[n])
+					i += end + len("⟧")
+					continue
+				}
+			}
+		}
+		out.WriteByte(html[i])
+		i++
+	}
+	return out.String()
+}
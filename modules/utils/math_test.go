@@ -0,0 +1,111 @@
+package utils
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRenderMath(t *testing.T) {
+	tests := []struct {
+		src  string
+		want string
+	}{
+		{`x^2`, `<msup><mi>x</mi><mn>2</mn></msup>`},
+		{`a-b`, `<mi>a</mi><mo>−</mo><mi>b</mi>`},
+		{`\frac{a}{b}`, `<mfrac><mrow><mi>a</mi></mrow><mrow><mi>b</mi></mrow></mfrac>`},
+		{`a<b`, `<mi>a</mi><mo>&lt;</mo><mi>b</mi>`},
+		// text of commands is escaped
+		{`\text{<b>&</b>}`, `<mtext>&lt;b&gt;&amp;&lt;/b&gt;</mtext>`},
+		{`\operatorname{<i>}`, `<mi>&lt;i&gt;</mi>`},
+		{`\mathbb{<}`, `<mrow><mi mathvariant="double-struck">&lt;</mi></mrow>`},
+		{`\mathbb{R}`, `<mrow><mi mathvariant="double-struck">R</mi></mrow>`},
+	}
+	for _, test := range tests {
+		node, err := RenderMath(test.src, false)
+		ThrowFailNow(t, err)
+		ThrowFail(t, AssertIs(strings.Contains(node, "<mrow>"+test.want+"</mrow>"), true))
+	}
+
+	// source is escaped in the annotation
+	node, err := RenderMath(`\text{"</annotation>"}`, true)
+	ThrowFailNow(t, err)
+	ThrowFail(t, AssertIs(strings.HasPrefix(node, `<math xmlns="http://www.w3.org/1998/Math/MathML" display="block">`), true))
+	ThrowFail(t, AssertIs(strings.Contains(node, `<annotation encoding="application/x-tex">\text{&#34;&lt;/annotation&gt;&#34;}</annotation>`), true))
+
+	// malformed, too long and too deep math
+	for _, src := range []string{
+		`\frac{a}`, `{a`, `a}`, `\unknown`, `\text{a`, `#`,
+		strings.Repeat("x", mathMaxLength+1),
+		strings.Repeat("{", mathMaxDepth+1) + "x" + strings.Repeat("}", mathMaxDepth+1),
+	} {
+		_, err := RenderMath(src, false)
+		ThrowFail(t, AssertIs(err, errMath))
+	}
+	_, err = RenderMath(strings.Repeat("x", mathMaxLength), false)
+	ThrowFail(t, err)
+}
+
+func TestExtractMath(t *testing.T) {
+	tests := []struct {
+		md    string
+		want  string
+		count int
+	}{
+		{"no math", "no math", 0},
+		{"$x$ and $$y$$", "⟦wegomath0⟧ and ⟦wegomath1⟧", 2},
+		// prices and spaced dollars are not math
+		{"it costs $5 and $10", "it costs $5 and $10", 0},
+		{"$ x$ and $x $", "$ x$ and $x $", 0},
+		{`\$x$`, `&#36;x$`, 0},
+		// malformed math is kept
+		{`$\frac{a}$`, `$\frac{a}$`, 0},
+		// code spans and code blocks are kept
+		{"`$x$` and ``a ` $y$``", "`$x$` and ``a ` $y$``", 0},
+		{"```\n$x$\n```\n$y$", "```\n$x$\n```\n⟦wegomath0⟧", 1},
+		{"~~~\n$x$\n~~~", "~~~\n$x$\n~~~", 0},
+		{"text\n\n    $x$\n", "text\n\n    $x$\n", 0},
+	}
+	for _, test := range tests {
+		md, maths := extractMath(test.md)
+		ThrowFail(t, AssertIs(md, test.want))
+		ThrowFail(t, AssertIs(len(maths.nodes), test.count))
+	}
+
+	// placeholders typed by users don't look like the ones of math
+	md, maths := extractMath("⟦wegomath0⟧ $x$")
+	ThrowFail(t, AssertIs(maths.key != "wegomath", true))
+	ThrowFail(t, AssertIs(md, "⟦wegomath0⟧ ⟦"+maths.key+"0⟧"))
+}
+
+func TestRestoreMath(t *testing.T) {
+	maths := &mathNodes{key: "wegomath", nodes: []string{"<math>0</math>", "<math>1</math>"}}
+	tests := []struct {
+		html string
+		want string
+	}{
+		{"<p>⟦wegomath1⟧ ⟦wegomath0⟧</p>", "<p><math>1</math> <math>0</math></p>"},
+		// placeholders in tags, out of range or malformed are kept
+		{`<a title="⟦wegomath0⟧">⟦wegomath2⟧</a>`, `<a title="⟦wegomath0⟧">⟦wegomath2⟧</a>`},
+		{"⟦wegomath-1⟧ ⟦wegomath01⟧ ⟦wegomathx0⟧ ⟦wegomath0", "⟦wegomath-1⟧ ⟦wegomath01⟧ ⟦wegomathx0⟧ ⟦wegomath0"},
+	}
+	for _, test := range tests {
+		ThrowFail(t, AssertIs(restoreMath(test.html, maths), test.want))
+	}
+	ThrowFail(t, AssertIs(restoreMath("⟦wegomath0⟧", &mathNodes{}), "⟦wegomath0⟧"))
+}
+
+func TestRenderMarkdownMath(t *testing.T) {
+	body := RenderMarkdown("$x^2$ costs $5 and $10")
+	ThrowFail(t, AssertIs(strings.Contains(body, `<msup><mi>x</mi><mn>2</mn></msup>`), true))
+	ThrowFail(t, AssertIs(strings.Contains(body, "costs $5 and $10"), true))
+
+	// forged placeholders stay as text, in code as well
+	body = RenderMarkdown("⟦wegomath0⟧ `⟦wegomath0⟧` $x$")
+	ThrowFail(t, AssertIs(strings.Count(body, "<math "), 1))
+	ThrowFail(t, AssertIs(strings.Contains(body, "<p>⟦wegomath0⟧ <code>⟦wegomath0⟧</code> <math "), true))
+
+	// MathML typed by users is sanitized
+	body = RenderMarkdown(`<math><mi onclick="alert(1)">a</mi><maction actiontype="statusline">b</maction></math>`)
+	ThrowFail(t, AssertIs(strings.Contains(body, "onclick"), false))
+	ThrowFail(t, AssertIs(strings.Contains(body, "maction"), false))
+}
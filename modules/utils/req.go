@@ -1,29 +1,130 @@
 package utils
 
 import (
+	"net"
 	"net/http"
+	"sort"
+	"strconv"
 	"strings"
+
+	"github.com/missdeer/wego/setting"
 )
 
+// ParseIPNet parses a cidr range like 10.0.0.0/8,
+// a single ip address is treated as a range only contains itself.
+func ParseIPNet(s string) (*net.IPNet, error) {
+	s = strings.TrimSpace(s)
+	if strings.IndexByte(s, '/') != -1 {
+		_, ipNet, err := net.ParseCIDR(s)
+		return ipNet, err
+	}
+
+	ip := net.ParseIP(s)
+	if ip == nil {
+		return nil, &net.ParseError{Type: "IP address", Text: s}
+	}
+	if ip4 := ip.To4(); ip4 != nil {
+		return &net.IPNet{IP: ip4, Mask: net.CIDRMask(32, 32)}, nil
+	}
+	return &net.IPNet{IP: ip, Mask: net.CIDRMask(128, 128)}, nil
+}
+
+// IsTrustedProxy checks if the ip belongs to setting.TrustedProxies.
+func IsTrustedProxy(ip string) bool {
+	addr := net.ParseIP(ip)
+	if addr == nil {
+		return false
+	}
+	for _, proxy := range setting.TrustedProxies {
+		if len(strings.TrimSpace(proxy)) == 0 {
+			continue
+		}
+		if ipNet, err := ParseIPNet(proxy); err == nil && ipNet.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// trim the port from host:port, the value is returned as is if no port found.
+func splitHost(addr string) string {
+	addr = strings.TrimSpace(addr)
+	if host, _, err := net.SplitHostPort(addr); err == nil {
+		return host
+	}
+	return addr
+}
+
 // Proxy returns proxy client ips slice.
+// X-Forwarded-For is only honored when the request comes from a trusted proxy.
 func Proxy(req *http.Request) []string {
+	if !IsTrustedProxy(splitHost(req.RemoteAddr)) {
+		return []string{}
+	}
 	if ips := req.Header.Get("X-Forwarded-For"); ips != "" {
-		return strings.Split(ips, ",")
+		proxy := strings.Split(ips, ",")
+		for i, ip := range proxy {
+			proxy[i] = splitHost(ip)
+		}
+		return proxy
 	}
 	return []string{}
 }
 
+// IP returns the client ip. Proxy ips are walked from right to left,
+// the first one which is not a trusted proxy is the client.
 func IP(req *http.Request) string {
+	ip := splitHost(req.RemoteAddr)
 	ips := Proxy(req)
-	if len(ips) > 0 && ips[0] != "" {
-		rip := strings.Split(ips[0], ":")
-		return rip[0]
+	for i := len(ips) - 1; i >= 0; i-- {
+		if net.ParseIP(ips[i]) == nil {
+			break
+		}
+		ip = ips[i]
+		if !IsTrustedProxy(ip) {
+			break
+		}
+	}
+	if net.ParseIP(ip) == nil {
+		return "127.0.0.1"
 	}
-	ip := strings.Split(req.RemoteAddr, ":")
-	if len(ip) > 0 {
-		if ip[0] != "[" {
-			return ip[0]
+	return ip
+}
+
+// AcceptLanguages parses an Accept-Language header into language tags
+// ordered by their quality, tags with q=0 are dropped.
+func AcceptLanguages(header string) []string {
+	type tagQ struct {
+		tag string
+		q   float64
+	}
+	tags := make([]tagQ, 0)
+	for _, part := range strings.Split(header, ",") {
+		fields := strings.Split(part, ";")
+		tag := strings.TrimSpace(fields[0])
+		if len(tag) == 0 || tag == "*" {
+			continue
 		}
+		q := 1.0
+		for _, param := range fields[1:] {
+			param = strings.TrimSpace(param)
+			if strings.HasPrefix(param, "q=") {
+				if f, err := strconv.ParseFloat(param[2:], 64); err == nil {
+					q = f
+				}
+			}
+		}
+		if q <= 0 {
+			continue
+		}
+		tags = append(tags, tagQ{tag, q})
+	}
+	sort.SliceStable(tags, func(i, j int) bool {
+		return tags[i].q > tags[j].q
+	})
+	langs := make([]string, 0, len(tags))
+	for _, t := range tags {
+		langs = append(langs, t.tag)
 	}
-	return "127.0.0.1"
+	return langs
 }
@@ -0,0 +1,60 @@
+package utils
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/missdeer/wego/setting"
+)
+
+func newProxyRequest(remoteAddr, forwardedFor string) *http.Request {
+	req, _ := http.NewRequest("GET", "/", nil)
+	req.RemoteAddr = remoteAddr
+	if len(forwardedFor) > 0 {
+		req.Header.Set("X-Forwarded-For", forwardedFor)
+	}
+	return req
+}
+
+func TestParseIPNet(t *testing.T) {
+	ipNet, err := ParseIPNet("10.1.0.0/16")
+	ThrowFailNow(t, err)
+	ThrowFail(t, AssertIs(ipNet.String(), "10.1.0.0/16"))
+
+	ipNet, err = ParseIPNet(" 192.168.1.1 ")
+	ThrowFailNow(t, err)
+	ThrowFail(t, AssertIs(ipNet.String(), "192.168.1.1/32"))
+
+	ipNet, err = ParseIPNet("2001:db8::1")
+	ThrowFailNow(t, err)
+	ThrowFail(t, AssertIs(ipNet.String(), "2001:db8::1/128"))
+
+	_, err = ParseIPNet("10.1.0")
+	ThrowFail(t, AssertIs(err != nil, true))
+}
+
+func TestIP(t *testing.T) {
+	setting.TrustedProxies = []string{"127.0.0.1", "10.0.0.0/8"}
+
+	// not trusted peer, X-Forwarded-For is ignored
+	ThrowFail(t, AssertIs(IP(newProxyRequest("8.8.8.8:1234", "1.2.3.4")), "8.8.8.8"))
+	ThrowFail(t, AssertIs(len(Proxy(newProxyRequest("8.8.8.8:1234", "1.2.3.4"))), 0))
+
+	// trusted peer without header
+	ThrowFail(t, AssertIs(IP(newProxyRequest("127.0.0.1:1234", "")), "127.0.0.1"))
+
+	// forged left most address is skipped
+	ThrowFail(t, AssertIs(IP(newProxyRequest("127.0.0.1:1234", "6.6.6.6, 1.2.3.4, 10.0.0.2")), "1.2.3.4"))
+
+	// address with port and ipv6 peer
+	ThrowFail(t, AssertIs(IP(newProxyRequest("10.0.0.1:1234", "1.2.3.4:5678")), "1.2.3.4"))
+	ThrowFail(t, AssertIs(IP(newProxyRequest("[2001:db8::1]:1234", "1.2.3.4")), "2001:db8::1"))
+}
+
+func TestAcceptLanguages(t *testing.T) {
+	langs := AcceptLanguages("zh-CN;q=0.8, en-US, en;q=0.9, fr;q=0, *;q=0.1")
+	ThrowFail(t, AssertIs(strings.Join(langs, ","), "en-US,en,zh-CN"))
+
+	ThrowFail(t, AssertIs(len(AcceptLanguages("")), 0))
+}
@@ -14,11 +14,16 @@
 
 package admin
 
+import (
+	"github.com/missdeer/wego/setting"
+)
+
 type AdminDashboard struct {
 	BaseAdminRouter
 }
 
 func (this *AdminDashboard) Get() error {
 	this.Data["consoleAdmin"] = true
+	this.Data["UntranslatedKeys"] = setting.UntranslatedKeys()
 	return this.Render("admin/dashboard.html", this.Data)
 }
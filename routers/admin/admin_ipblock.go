@@ -0,0 +1,134 @@
+package admin
+
+import (
+	"fmt"
+
+	"github.com/lunny/log"
+	"github.com/missdeer/wego/models"
+	"github.com/missdeer/wego/modules/ipblock"
+	"github.com/missdeer/wego/modules/utils"
+)
+
+type IpBlockAdminRouter struct {
+	ModelAdminRouter
+	object models.IpBlock
+}
+
+func (this *IpBlockAdminRouter) Before() {
+	this.Params().Set(":model", "ipblock")
+	this.ModelAdminRouter.Before()
+}
+
+func (this *IpBlockAdminRouter) Object() interface{} {
+	return &this.object
+}
+
+// reload block list after changed
+func (this *IpBlockAdminRouter) reload() {
+	if err := ipblock.Reload(); err != nil {
+		log.Error("ipblock Reload: ", err)
+	}
+}
+
+type IpBlockAdminList struct {
+	IpBlockAdminRouter
+}
+
+// view for list model data
+func (this *IpBlockAdminList) Get() {
+	var blocks []models.IpBlock
+	sess := models.ORM().Desc("created")
+	if err := this.SetObjects(sess, &blocks); err != nil {
+		this.Data["Error"] = err
+		log.Error(err)
+	}
+}
+
+type IpBlockAdminNew struct {
+	IpBlockAdminRouter
+}
+
+// view for create object
+func (this *IpBlockAdminNew) Get() {
+	form := ipblock.IpBlockAdminForm{Create: true, BlockRegister: true, BlockWrite: true}
+	this.SetFormSets(&form)
+}
+
+// view for new object save
+func (this *IpBlockAdminNew) Post() {
+	form := ipblock.IpBlockAdminForm{Create: true}
+	if this.ValidFormSets(&form) == false {
+		return
+	}
+
+	var block models.IpBlock
+	form.SetToIpBlock(&block)
+	if err := models.Insert(&block); err == nil {
+		this.reload()
+		this.FlashRedirect(fmt.Sprintf("/admin/ipblock/%d", block.Id), 302, "CreateSuccess")
+		return
+	} else {
+		log.Error(err)
+		this.Data["Error"] = err
+	}
+}
+
+type IpBlockAdminEdit struct {
+	IpBlockAdminRouter
+}
+
+// view for edit object
+func (this *IpBlockAdminEdit) Get() {
+	form := ipblock.IpBlockAdminForm{}
+	form.SetFromIpBlock(&this.object)
+	this.SetFormSets(&form)
+}
+
+// view for update object
+func (this *IpBlockAdminEdit) Post() {
+	form := ipblock.IpBlockAdminForm{}
+	if this.ValidFormSets(&form) == false {
+		return
+	}
+
+	// get changed field names
+	changes := utils.FormChanges(&this.object, &form)
+
+	url := fmt.Sprintf("/admin/ipblock/%d", this.object.Id)
+
+	// update changed fields only
+	if len(changes) > 0 {
+		form.SetToIpBlock(&this.object)
+		if err := models.UpdateById(this.object.Id, this.object, models.Obj2Table(changes)...); err == nil {
+			this.reload()
+			this.FlashRedirect(url, 302, "UpdateSuccess")
+			return
+		} else {
+			log.Error(err)
+			this.Data["Error"] = err
+		}
+	} else {
+		this.Redirect(url, 302)
+	}
+}
+
+type IpBlockAdminDelete struct {
+	IpBlockAdminRouter
+}
+
+// view for delete object
+func (this *IpBlockAdminDelete) Post() {
+	if this.FormOnceNotMatch() {
+		return
+	}
+
+	// delete object
+	if err := models.DeleteById(this.object.Id, new(models.IpBlock)); err == nil {
+		this.reload()
+		this.FlashRedirect("/admin/ipblock", 302, "DeleteSuccess")
+		return
+	} else {
+		log.Error(err)
+		this.Data["Error"] = err
+	}
+}
@@ -0,0 +1,129 @@
+package admin
+
+import (
+	"fmt"
+
+	"github.com/lunny/log"
+	"github.com/missdeer/wego/models"
+	"github.com/missdeer/wego/modules/menu"
+	"github.com/missdeer/wego/modules/utils"
+)
+
+type MenuAdminRouter struct {
+	ModelAdminRouter
+	object models.Menu
+}
+
+func (this *MenuAdminRouter) Before() {
+	this.Params().Set(":model", "menu")
+	this.ModelAdminRouter.Before()
+}
+
+func (this *MenuAdminRouter) Object() interface{} {
+	return &this.object
+}
+
+type MenuAdminList struct {
+	MenuAdminRouter
+}
+
+func (this *MenuAdminList) Get() {
+	var menus []models.Menu
+	sess := models.ORM().Asc("position")
+	if err := this.SetObjects(sess, &menus); err != nil {
+		this.Data["Error"] = err
+		log.Error(err)
+	}
+}
+
+type MenuAdminNew struct {
+	MenuAdminRouter
+}
+
+func (this *MenuAdminNew) Get() {
+	form := menu.MenuAdminForm{Create: true}
+	this.SetFormSets(&form)
+}
+
+func (this *MenuAdminNew) Post() {
+	form := menu.MenuAdminForm{Create: true}
+	if this.ValidFormSets(&form) == false {
+		return
+	}
+
+	var m models.Menu
+	form.SetToMenu(&m)
+	if err := models.Insert(&m); err == nil {
+		models.ClearMenuCache()
+		this.FlashRedirect(fmt.Sprintf("/admin/menu/%d", m.Id), 302, "CreateSuccess")
+		return
+	} else {
+		log.Error(err)
+		this.Data["Error"] = err
+	}
+}
+
+type MenuAdminEdit struct {
+	MenuAdminRouter
+}
+
+func (this *MenuAdminEdit) Get() {
+	form := menu.MenuAdminForm{}
+	form.SetFromMenu(&this.object)
+	this.SetFormSets(&form)
+}
+
+func (this *MenuAdminEdit) Post() {
+	form := menu.MenuAdminForm{Id: int(this.object.Id)}
+	if this.ValidFormSets(&form) == false {
+		return
+	}
+
+	// get changed field names
+	changes := utils.FormChanges(&this.object, &form)
+	if int64(form.Parent) != this.object.ParentId {
+		changes = append(changes, "ParentId")
+	}
+
+	url := fmt.Sprintf("/admin/menu/%d", this.object.Id)
+
+	// update changed fields only
+	if len(changes) > 0 {
+		form.SetToMenu(&this.object)
+		if err := models.UpdateById(this.object.Id, this.object, models.Obj2Table(changes)...); err == nil {
+			models.ClearMenuCache()
+			this.FlashRedirect(url, 302, "UpdateSuccess")
+			return
+		} else {
+			log.Error(err)
+			this.Data["Error"] = err
+		}
+	} else {
+		this.Redirect(url, 302)
+	}
+}
+
+type MenuAdminDelete struct {
+	MenuAdminRouter
+}
+
+func (this *MenuAdminDelete) Post() {
+	if this.FormOnceNotMatch() {
+		return
+	}
+
+	// the dropdown items are deleted with their menu
+	if _, err := models.ORM().Where("parent_id = ?", this.object.Id).Delete(new(models.Menu)); err != nil {
+		log.Error(err)
+		this.Data["Error"] = err
+		return
+	}
+	if err := models.DeleteById(this.object.Id, new(models.Menu)); err == nil {
+		models.ClearMenuCache()
+		this.FlashRedirect("/admin/menu", 302, "DeleteSuccess")
+		return
+	} else {
+		log.Error(err)
+		this.Data["Error"] = err
+	}
+}
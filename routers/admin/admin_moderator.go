@@ -0,0 +1,116 @@
+package admin
+
+import (
+	"fmt"
+
+	"github.com/lunny/log"
+	"github.com/missdeer/wego/models"
+	"github.com/missdeer/wego/modules/moderator"
+)
+
+type ModeratorAdminRouter struct {
+	ModelAdminRouter
+	object models.Moderator
+}
+
+func (this *ModeratorAdminRouter) Before() {
+	this.Params().Set(":model", "moderator")
+	this.ModelAdminRouter.Before()
+}
+
+func (this *ModeratorAdminRouter) Object() interface{} {
+	return &this.object
+}
+
+type ModeratorAdminList struct {
+	ModeratorAdminRouter
+}
+
+// view for list model data
+func (this *ModeratorAdminList) Get() {
+	var moderators []models.Moderator
+	sess := models.ORM().Desc("user_id")
+	if err := this.SetObjects(sess, &moderators); err != nil {
+		this.Data["Error"] = err
+		log.Error(err)
+	}
+}
+
+type ModeratorAdminNew struct {
+	ModeratorAdminRouter
+}
+
+// view for create object
+func (this *ModeratorAdminNew) Get() {
+	form := moderator.ModeratorAdminForm{Create: true}
+	this.SetFormSets(&form)
+}
+
+// view for new object save
+func (this *ModeratorAdminNew) Post() {
+	form := moderator.ModeratorAdminForm{Create: true}
+	if this.ValidFormSets(&form) == false {
+		return
+	}
+
+	var mod models.Moderator
+	form.SetToModerator(&mod)
+	if err := models.Insert(&mod); err == nil {
+		this.FlashRedirect(fmt.Sprintf("/admin/moderator/%d", mod.Id), 302, "CreateSuccess")
+		return
+	} else {
+		log.Error(err)
+		this.Data["Error"] = err
+	}
+}
+
+type ModeratorAdminEdit struct {
+	ModeratorAdminRouter
+}
+
+// view for edit object
+func (this *ModeratorAdminEdit) Get() {
+	form := moderator.ModeratorAdminForm{}
+	form.SetFromModerator(&this.object)
+	this.SetFormSets(&form)
+}
+
+// view for update object
+func (this *ModeratorAdminEdit) Post() {
+	form := moderator.ModeratorAdminForm{}
+	if this.ValidFormSets(&form) == false {
+		return
+	}
+
+	url := fmt.Sprintf("/admin/moderator/%d", this.object.Id)
+
+	// form fields don't map to columns, so update the whole role
+	form.SetToModerator(&this.object)
+	if err := models.UpdateById(this.object.Id, this.object, "user_id", "category_id", "topic_id", "perms"); err == nil {
+		this.FlashRedirect(url, 302, "UpdateSuccess")
+		return
+	} else {
+		log.Error(err)
+		this.Data["Error"] = err
+	}
+}
+
+type ModeratorAdminDelete struct {
+	ModeratorAdminRouter
+}
+
+// view for delete object
+func (this *ModeratorAdminDelete) Post() {
+	if this.FormOnceNotMatch() {
+		return
+	}
+
+	// delete object
+	if err := models.DeleteById(this.object.Id, new(models.Moderator)); err == nil {
+		this.FlashRedirect("/admin/moderator", 302, "DeleteSuccess")
+		return
+	} else {
+		log.Error(err)
+		this.Data["Error"] = err
+	}
+}
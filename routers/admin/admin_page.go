@@ -72,6 +72,10 @@ func (this *PageAdminNew) Post() {
 	var a models.Page
 	form.SetToPage(&a)
 	if err := models.Insert(&a); err == nil {
+		// the history and the blame start from the created page
+		if err := models.AddPageRevision(nil, &a, this.User.Id, ""); err != nil {
+			log.Error("AddPageRevision:", err)
+		}
 		this.FlashRedirect(fmt.Sprintf("/admin/page/%d", a.Id), 302, "CreateSuccess")
 		return
 	} else {
@@ -93,20 +97,33 @@ func (this *PageAdminEdit) Get() {
 
 // view for update object
 func (this *PageAdminEdit) Post() {
-	form := page.PageAdminForm{}
+	form := page.PageAdminForm{Id: int(this.object.Id)}
 	if this.ValidFormSets(&form) == false {
 		return
 	}
 
 	// get changed field names
 	changes := utils.FormChanges(&this.object, &form)
+	if int64(form.Parent) != this.object.ParentId {
+		changes = append(changes, "ParentId")
+	}
 
 	url := fmt.Sprintf("/admin/page/%d", this.object.Id)
 
 	// update changed fields only
 	if len(changes) > 0 {
+		original := this.object
 		form.SetToPage(&this.object)
+		if original.Content != this.object.Content {
+			changes = append(changes, "ContentCache")
+		}
 		if err := models.UpdateById(this.object.Id, this.object, models.Obj2Table(changes)...); err == nil {
+			// keep the edits of content as revisions
+			if original.Content != this.object.Content || original.Title != this.object.Title {
+				if err := models.AddPageRevision(&original, &this.object, this.User.Id, ""); err != nil {
+					log.Error("AddPageRevision:", err)
+				}
+			}
 			this.FlashRedirect(url, 302, "UpdateSuccess")
 			return
 		} else {
@@ -129,7 +146,7 @@ func (this *PageAdminDelete) Post() {
 	}
 
 	// delete object
-	if err := models.DeleteById(this.object.Id, this.object); err == nil {
+	if err := models.DeletePage(&this.object); err == nil {
 		this.FlashRedirect("/admin/page", 302, "DeleteSuccess")
 		return
 	} else {
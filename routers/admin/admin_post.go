@@ -113,8 +113,15 @@ func (this *PostAdminEdit) Post() {
 	if len(changes) > 0 {
 		//fix the bug of category not updated
 		changes = append(changes, "Category")
+		original := this.object
 		form.SetToPost(&this.object)
-		if err := models.UpdateById(this.object.Id, this.object, models.Obj2Table(changes)...); err == nil {
+		var err error
+		if original.Title != this.object.Title || original.Content != this.object.Content {
+			err = models.UpdatePostRevision(&original, &this.object, this.User.Id, "", models.Obj2Table(changes)...)
+		} else {
+			err = models.UpdateById(this.object.Id, this.object, models.Obj2Table(changes)...)
+		}
+		if err == nil {
 			this.FlashRedirect(url, 302, "UpdateSuccess")
 			return
 		} else {
@@ -136,6 +143,17 @@ func (this *PostAdminDelete) Get() {
 		return
 	}
 
+	// untag the post before delete to keep the counts of tags
+	if err := models.SetPostTags(&this.object, nil); err != nil {
+		log.Error(err)
+	}
+	if err := models.DeletePoll(&this.object); err != nil {
+		log.Error(err)
+	}
+	if err := models.DeletePostReads(&this.object); err != nil {
+		log.Error(err)
+	}
+
 	// delete object
 	if err := models.DeleteById(this.object.Id, this.object); err == nil {
 		this.FlashRedirect("/admin/post", 302, "DeleteSuccess")
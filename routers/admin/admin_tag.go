@@ -0,0 +1,146 @@
+package admin
+
+import (
+	"fmt"
+
+	"github.com/lunny/log"
+	"github.com/missdeer/wego/models"
+	"github.com/missdeer/wego/modules/post"
+)
+
+type TagAdminRouter struct {
+	ModelAdminRouter
+	object models.Tag
+}
+
+func (this *TagAdminRouter) Before() {
+	this.Params().Set(":model", "tag")
+	this.ModelAdminRouter.Before()
+}
+
+func (this *TagAdminRouter) Object() interface{} {
+	return &this.object
+}
+
+// make tag the synonym of target, its posts are merged into target
+func (this *TagAdminRouter) mergeTag(tag *models.Tag, targetId int64) error {
+	target, err := models.GetTagById(targetId)
+	if err != nil {
+		return err
+	}
+	return models.MergeTag(tag, target)
+}
+
+type TagAdminList struct {
+	TagAdminRouter
+}
+
+// view for list model data
+func (this *TagAdminList) Get() {
+	var tags []models.Tag
+	sess := models.ORM().Desc("posts")
+	if err := this.SetObjects(sess, &tags); err != nil {
+		this.Data["Error"] = err
+		log.Error(err)
+	}
+}
+
+type TagAdminNew struct {
+	TagAdminRouter
+}
+
+// view for create object
+func (this *TagAdminNew) Get() {
+	form := post.TagAdminForm{Create: true}
+	this.SetFormSets(&form)
+}
+
+// view for new object save
+func (this *TagAdminNew) Post() {
+	form := post.TagAdminForm{Create: true}
+	if this.ValidFormSets(&form) == false {
+		return
+	}
+
+	var tag models.Tag
+	form.SetToTag(&tag)
+	if err := models.Insert(&tag); err != nil {
+		log.Error(err)
+		this.Data["Error"] = err
+		return
+	}
+
+	if form.Synonym > 0 {
+		if err := this.mergeTag(&tag, form.Synonym); err != nil {
+			log.Error(err)
+			this.Data["Error"] = err
+			return
+		}
+	}
+	this.FlashRedirect(fmt.Sprintf("/admin/tag/%d", tag.Id), 302, "CreateSuccess")
+}
+
+type TagAdminEdit struct {
+	TagAdminRouter
+}
+
+// view for edit object
+func (this *TagAdminEdit) Get() {
+	form := post.TagAdminForm{}
+	form.SetFromTag(&this.object)
+	this.SetFormSets(&form)
+}
+
+// view for update object
+func (this *TagAdminEdit) Post() {
+	form := post.TagAdminForm{Id: this.object.Id}
+	if this.ValidFormSets(&form) == false {
+		return
+	}
+
+	url := fmt.Sprintf("/admin/tag/%d", this.object.Id)
+
+	form.SetToTag(&this.object)
+	if err := models.UpdateById(this.object.Id, this.object, "name", "slug", "intro"); err != nil {
+		log.Error(err)
+		this.Data["Error"] = err
+		return
+	}
+
+	// a new synonym merges the tag, an empty one makes it a tag of its own again
+	if form.Synonym != this.object.SynonymId {
+		var err error
+		if form.Synonym > 0 {
+			err = this.mergeTag(&this.object, form.Synonym)
+		} else {
+			this.object.SynonymId = 0
+			err = models.UpdateById(this.object.Id, this.object, "synonym_id")
+		}
+		if err != nil {
+			log.Error(err)
+			this.Data["Error"] = err
+			return
+		}
+	}
+	this.FlashRedirect(url, 302, "UpdateSuccess")
+}
+
+type TagAdminDelete struct {
+	TagAdminRouter
+}
+
+// view for delete object
+func (this *TagAdminDelete) Post() {
+	if this.FormOnceNotMatch() {
+		return
+	}
+
+	// delete object
+	if err := models.DeleteTag(&this.object); err == nil {
+		this.FlashRedirect("/admin/tag", 302, "DeleteSuccess")
+		return
+	} else {
+		log.Error(err)
+		this.Data["Error"] = err
+	}
+}
@@ -52,6 +52,18 @@ func (this *ModelGet) Get() {
 			data = append(data, []interface{}{user.Id, user.UserName})
 			return nil
 		})
+	} else if model == "Page" {
+		models.ORM().Iterate(&models.Page{Id: id}, func(idx int, bean interface{}) error {
+			page := bean.(*models.Page)
+			data = append(data, []interface{}{page.Id, page.Title})
+			return nil
+		})
+	} else if model == "Tag" {
+		models.ORM().Iterate(&models.Tag{Id: id}, func(idx int, bean interface{}) error {
+			tag := bean.(*models.Tag)
+			data = append(data, []interface{}{tag.Id, tag.Name})
+			return nil
+		})
 	}
 }
 
@@ -78,7 +90,8 @@ func (this *ModelSelect) Post() {
 		this.ServeJson(this.Data)
 	}()
 
-	if len(search) < 3 {
+	// tag names can be short as go
+	if (len(search) < 3 && model != "Tag") || len(search) == 0 {
 		return
 	}
 
@@ -89,5 +102,19 @@ func (this *ModelSelect) Post() {
 				data = append(data, []interface{}{user.Id, user.UserName})
 				return nil
 			})
+	} else if model == "Page" {
+		models.ORM().Limit(10).Where("title like ?", "%"+search+"%").
+			Iterate(&models.Page{}, func(idx int, bean interface{}) error {
+				page := bean.(*models.Page)
+				data = append(data, []interface{}{page.Id, page.Title})
+				return nil
+			})
+	} else if model == "Tag" {
+		models.ORM().Limit(10).Where("name like ? AND synonym_id = ?", "%"+search+"%", 0).
+			Iterate(&models.Tag{}, func(idx int, bean interface{}) error {
+				tag := bean.(*models.Tag)
+				data = append(data, []interface{}{tag.Id, tag.Name})
+				return nil
+			})
 	}
 }
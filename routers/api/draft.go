@@ -0,0 +1,102 @@
+package api
+
+import (
+	"strings"
+	"time"
+
+	"github.com/missdeer/wego/models"
+	"github.com/missdeer/wego/modules/utils"
+	"github.com/missdeer/wego/routers/base"
+	"github.com/missdeer/wego/setting"
+	"github.com/tango-contrib/xsrf"
+)
+
+// autosave drafts of new posts, post edits and comments
+type Draft struct {
+	base.BaseRouter
+	xsrf.NoCheck
+}
+
+// read the type and post of draft from request, new post drafts have no post
+func (this *Draft) draftTarget() (int, int64, bool) {
+	typ, err := this.GetInt("type")
+	if err != nil {
+		return 0, 0, false
+	}
+
+	switch typ {
+	case setting.DRAFT_TYPE_POST:
+		return int(typ), 0, true
+	case setting.DRAFT_TYPE_EDIT, setting.DRAFT_TYPE_COMMENT:
+		postId, err := this.GetInt("post")
+		if err != nil {
+			this.Logger.Error("post value is not int:", this.GetString("post"))
+			return 0, 0, false
+		}
+		if _, err := models.GetPostById(postId); err != nil {
+			return 0, 0, false
+		}
+		return int(typ), postId, true
+	}
+	return 0, 0, false
+}
+
+func (this *Draft) Post() {
+	if this.CheckActiveRedirect() {
+		return
+	}
+
+	if !this.IsAjax() {
+		return
+	}
+
+	result := map[string]interface{}{
+		"success": false,
+	}
+	action := this.GetString("action")
+	switch action {
+	case "save":
+		typ, postId, ok := this.draftTarget()
+		if !ok {
+			break
+		}
+
+		draft := models.Draft{
+			UserId:  this.User.Id,
+			Type:    typ,
+			PostId:  postId,
+			Title:   this.GetString("title"),
+			Content: this.GetString("content"),
+		}
+		if len([]rune(draft.Title)) > 60 {
+			draft.Title = string([]rune(draft.Title)[:60])
+		}
+		if topicId, err := this.GetInt("topic"); err == nil {
+			draft.TopicId = topicId
+		}
+		if base, err := this.GetInt("base"); err == nil {
+			draft.Base = time.Unix(base, 0)
+		}
+
+		//nothing to keep
+		if len(strings.TrimSpace(draft.Title)) == 0 && len(strings.TrimSpace(draft.Content)) == 0 {
+			if models.DeleteDraft(this.User.Id, typ, postId) == nil {
+				result["success"] = true
+			}
+			break
+		}
+
+		if models.SaveDraft(&draft) == nil {
+			result["success"] = true
+			result["saved"] = utils.Date(time.Now(), setting.DateTimeFormat)
+		}
+	case "delete":
+		if typ, postId, ok := this.draftTarget(); ok {
+			if models.DeleteDraft(this.User.Id, typ, postId) == nil {
+				result["success"] = true
+			}
+		}
+	}
+	this.Data["json"] = result
+	this.ServeJson(this.Data)
+}
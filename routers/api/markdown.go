@@ -15,16 +15,28 @@
 package api
 
 import (
+	"strconv"
+	"strings"
+
+	"github.com/missdeer/wego/models"
 	"github.com/missdeer/wego/modules/utils"
 	"github.com/missdeer/wego/routers/base"
 )
 
+// max number of posts whose titles are asked at once
+const maxPostTitles = 50
+
+// max number of wiki pages which are looked up at once
+const maxWikiPages = 50
+
 type Markdown struct {
 	base.BaseRouter
 }
 
 func (this *Markdown) Post() {
-	if this.CheckActiveRedirect() {
+	action := this.GetString("action")
+	// the titles of linked posts and missing wiki pages are shown to guests as well
+	if action != "post-titles" && action != "wiki-pages" && this.CheckActiveRedirect() {
 		return
 	}
 
@@ -32,12 +44,49 @@ func (this *Markdown) Post() {
 		result := map[string]interface{}{
 			"success": false,
 		}
-		action := this.GetString("action")
 		switch action {
 		case "preview":
 			content := this.GetString("content")
 			result["preview"] = utils.RenderMarkdown(content)
 			result["success"] = true
+		case "post-titles":
+			var ids []int64
+			for _, value := range strings.Split(this.GetString("ids"), ",") {
+				if id, err := strconv.ParseInt(strings.TrimSpace(value), 10, 64); err == nil && id > 0 {
+					ids = append(ids, id)
+				}
+			}
+			if len(ids) > maxPostTitles {
+				ids = ids[:maxPostTitles]
+			}
+			titles, err := models.FindPostTitles(&this.User, ids)
+			if err != nil {
+				this.Logger.Error("FindPostTitles error:", err)
+				break
+			}
+			data := make(map[string]string, len(titles))
+			for id, title := range titles {
+				data[strconv.FormatInt(id, 10)] = title
+			}
+			result["titles"] = data
+			result["success"] = true
+		case "wiki-pages":
+			var uris []string
+			for _, uri := range strings.Split(this.GetString("uris"), ",") {
+				if uri = strings.TrimSpace(uri); len(uri) > 0 && len(uri) <= 60 {
+					uris = append(uris, uri)
+				}
+			}
+			if len(uris) > maxWikiPages {
+				uris = uris[:maxWikiPages]
+			}
+			found, err := models.FindPublishedPageUris(uris)
+			if err != nil {
+				this.Logger.Error("FindPublishedPageUris error:", err)
+				break
+			}
+			result["pages"] = found
+			result["success"] = true
 		}
 		this.Data["json"] = result
 		this.ServeJson(this.Data)
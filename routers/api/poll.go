@@ -0,0 +1,136 @@
+package api
+
+import (
+	"github.com/missdeer/wego/models"
+	"github.com/missdeer/wego/modules/utils"
+	"github.com/missdeer/wego/routers/base"
+	"github.com/missdeer/wego/setting"
+	"github.com/tango-contrib/xsrf"
+)
+
+// read and vote polls of posts
+type Poll struct {
+	base.BaseRouter
+	xsrf.NoCheck
+}
+
+// load the poll of post from request, polls of hidden posts are only visible to the author
+func (this *Poll) loadPoll() (*models.Post, *models.Poll, bool) {
+	postId, err := this.GetInt("post")
+	if err != nil {
+		this.Logger.Error("post value is not int:", this.GetString("post"))
+		return nil, nil, false
+	}
+
+	post, err := models.GetPostById(postId)
+	if err != nil {
+		return nil, nil, false
+	}
+	if !models.CanViewPost(&this.User, post, models.GetPostPermission(&this.User, post)) {
+		return nil, nil, false
+	}
+
+	poll, err := models.GetPollByPostId(post.Id)
+	if err != nil {
+		return nil, nil, false
+	}
+	return post, poll, true
+}
+
+// poll data of response, votes are only included when the results are visible
+func (this *Poll) pollData(poll *models.Poll) map[string]interface{} {
+	var choices []int64
+	if this.IsLogin {
+		if vote, err := models.GetPollVote(poll.Id, this.User.Id); err == nil {
+			choices = vote.OptionIds()
+		}
+	}
+	showResults := poll.CanShowResults(len(choices) > 0)
+
+	options := make([]map[string]interface{}, 0)
+	for _, option := range poll.Options() {
+		data := map[string]interface{}{
+			"id":    option.Id,
+			"title": option.Title,
+		}
+		if showResults {
+			data["votes"] = option.Votes
+			data["percent"] = option.Percent(poll.Voters)
+		}
+		options = append(options, data)
+	}
+
+	data := map[string]interface{}{
+		"id":           poll.Id,
+		"post":         poll.PostId,
+		"multiple":     poll.Multiple,
+		"show_results": showResults,
+		"is_closed":    poll.IsClosed(),
+		"voted":        len(choices) > 0,
+		"choices":      choices,
+		"options":      options,
+	}
+	if !poll.Closed.IsZero() {
+		data["closed"] = utils.Date(poll.Closed, setting.DateTimeFormat)
+	}
+	if showResults {
+		data["voters"] = poll.Voters
+	}
+	return data
+}
+
+func (this *Poll) Post() {
+	result := map[string]interface{}{
+		"success": false,
+	}
+
+	defer func() {
+		this.Data["json"] = result
+		this.ServeJson(this.Data)
+	}()
+
+	if !this.IsAjax() {
+		return
+	}
+
+	switch this.GetString("action") {
+	case "get":
+		if _, poll, ok := this.loadPoll(); ok {
+			result["success"] = true
+			result["data"] = this.pollData(poll)
+		}
+	case "vote":
+		if this.CheckActiveRedirect() {
+			return
+		}
+		post, poll, ok := this.loadPoll()
+		if !ok {
+			return
+		}
+		if post.IsLock {
+			result["message"] = this.Tr("post.post_locked")
+			return
+		}
+
+		var optionIds []int64
+		for _, value := range this.Req().Form["option"] {
+			if id, err := utils.StrTo(value).Int64(); err == nil {
+				optionIds = append(optionIds, id)
+			}
+		}
+
+		switch err := models.VotePoll(poll, this.User.Id, optionIds); err {
+		case nil:
+			result["success"] = true
+			result["data"] = this.pollData(poll)
+		case models.ErrPollClosed:
+			result["message"] = this.Tr("post.poll_is_closed")
+		case models.ErrPollVoted:
+			result["message"] = this.Tr("post.poll_voted")
+		case models.ErrPollChoice:
+			result["message"] = this.Tr("post.poll_invalid_choice")
+		default:
+			this.Logger.Error("VotePoll error:", err)
+		}
+	}
+}
@@ -1,8 +1,12 @@
 package api
 
 import (
+	"time"
+
 	"github.com/missdeer/wego/models"
+	"github.com/missdeer/wego/modules/utils"
 	"github.com/missdeer/wego/routers/base"
+	"github.com/missdeer/wego/setting"
 	"github.com/tango-contrib/xsrf"
 )
 
@@ -11,6 +15,49 @@ type Post struct {
 	xsrf.NoCheck
 }
 
+// load the post from request and check if current user has the moderation permission on it
+func (this *Post) moderatePost(perm models.Permission) (*models.Post, bool) {
+	postId, err := this.GetInt("post")
+	if err != nil {
+		this.Logger.Error("post value is not int:", this.GetString("post"))
+		return nil, false
+	}
+
+	var post models.Post
+	if err := models.GetById(postId, &post); err != nil {
+		return nil, false
+	}
+
+	if !models.GetPostPermission(&this.User, &post).Has(perm) {
+		return nil, false
+	}
+	return &post, true
+}
+
+// check if current user can see the post or comment to vote, hidden and
+// scheduled targets are seen like on the post page
+func (this *Post) canVote(targetType int, id int64) bool {
+	postId := id
+	var comment *models.Comment
+	if targetType == setting.VOTE_TARGET_COMMENT {
+		var err error
+		if comment, err = models.GetCommentById(id); err != nil || comment.IsDelete {
+			return false
+		}
+		postId = comment.PostId
+	}
+
+	var post models.Post
+	if err := models.GetById(postId, &post); err != nil {
+		return false
+	}
+	perm := models.GetPostPermission(&this.User, &post)
+	if comment != nil && comment.IsHide && !perm.CanHideComment() {
+		return false
+	}
+	return models.CanViewPost(&this.User, &post, perm)
+}
+
 func (this *Post) Post() {
 	if this.CheckActiveRedirect() {
 		return
@@ -26,19 +73,118 @@ func (this *Post) Post() {
 	action := this.GetString("action")
 	switch action {
 	case "toggle-best":
-		if this.User.IsAdmin {
-			if postId, err := this.GetInt("post"); err == nil {
-				//set post best
+		if post, ok := this.moderatePost(models.PermBestPost); ok {
+			//set post best
+			post.IsBest = !post.IsBest
+			if models.UpdateById(post.Id, post, "is_best") == nil {
+				result["success"] = true
+			}
+		}
+	case "toggle-hide":
+		if post, ok := this.moderatePost(models.PermHidePost); ok {
+			post.IsHide = !post.IsHide
+			if models.UpdateById(post.Id, post, "is_hide") == nil {
+				result["success"] = true
+			}
+		}
+	case "toggle-lock":
+		if post, ok := this.moderatePost(models.PermLockPost); ok {
+			post.IsLock = !post.IsLock
+			if models.UpdateById(post.Id, post, "is_lock") == nil {
+				result["success"] = true
+			}
+		}
+	case "set-sticky":
+		if post, ok := this.moderatePost(models.PermPinPost); ok {
+			sticky, err := this.GetInt("sticky")
+			if err != nil || sticky < setting.STICKY_NONE || sticky > setting.STICKY_GLOBAL {
+				break
+			}
+
+			//empty expired means never expire
+			var expired time.Time
+			if value := this.GetString("expired"); len(value) > 0 {
+				if expired, err = utils.DateParse(value, setting.DateTimeFormat); err != nil {
+					break
+				}
+			}
+			if sticky == setting.STICKY_NONE {
+				expired = time.Time{}
+			}
+
+			post.Sticky = int(sticky)
+			post.StickyExpired = expired
+			if models.UpdateById(post.Id, post, "sticky", "sticky_expired") == nil {
+				result["success"] = true
+			}
+		}
+	case "toggle-hide-comment":
+		if commentId, err := this.GetInt("comment"); err == nil {
+			var comment models.Comment
+			if err := models.GetById(commentId, &comment); err == nil {
 				var post models.Post
-				if err := models.GetById(postId, &post); err == nil {
-					post.IsBest = !post.IsBest
-					if models.UpdateById(post.Id, post, "is_best") == nil {
-						result["success"] = true
+				if err := models.GetById(comment.PostId, &post); err == nil {
+					if models.GetPostPermission(&this.User, &post).CanHideComment() {
+						comment.IsHide = !comment.IsHide
+						if models.UpdateById(comment.Id, comment, "is_hide") == nil {
+							result["success"] = true
+						}
 					}
 				}
-			} else {
-				this.Logger.Error("post value is not int:", this.GetString("post"))
 			}
+		} else {
+			this.Logger.Error("comment value is not int:", this.GetString("comment"))
+		}
+	case "toggle-answer":
+		commentId, err := this.GetInt("comment")
+		if err != nil {
+			this.Logger.Error("comment value is not int:", this.GetString("comment"))
+			break
+		}
+		comment, err := models.GetCommentById(commentId)
+		if err != nil || comment.IsDelete {
+			break
+		}
+		var post models.Post
+		if err := models.GetById(comment.PostId, &post); err != nil {
+			break
+		}
+		//only questions of Q&A category have answers, accepted by author or moderator
+		if cat := post.Category(); cat == nil || !cat.IsQuestion {
+			break
+		}
+		if post.UserId != this.User.Id && !models.GetPostPermission(&this.User, &post).CanEditPost() {
+			break
+		}
+		if post.AnswerId == comment.Id {
+			comment = nil
+		}
+		if models.AcceptAnswer(&post, comment) == nil {
+			result["success"] = true
+		}
+	case "vote":
+		var targetType int
+		switch this.GetString("target") {
+		case "post":
+			targetType = setting.VOTE_TARGET_POST
+		case "comment":
+			targetType = setting.VOTE_TARGET_COMMENT
+		}
+		id, err := this.GetInt("id")
+		value, err2 := this.GetInt("value")
+		if targetType == 0 || err != nil || err2 != nil {
+			break
+		}
+		if !this.canVote(targetType, id) {
+			break
+		}
+		if score, err := models.SetVote(this.User.Id, targetType, id, int(value)); err == nil {
+			result["success"] = true
+			result["score"] = score
+		} else if err == models.ErrSelfVote {
+			result["message"] = this.Tr("post.vote_self")
+		} else {
+			this.Logger.Error("SetVote error:", err)
 		}
 	case "toggle-fav":
 		if postId, err := this.GetInt("post"); err == nil {
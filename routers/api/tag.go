@@ -0,0 +1,40 @@
+package api
+
+import (
+	"github.com/missdeer/wego/models"
+	"github.com/missdeer/wego/routers/base"
+	"github.com/tango-contrib/xsrf"
+)
+
+// autocomplete of tags
+type Tag struct {
+	base.BaseRouter
+	xsrf.NoCheck
+}
+
+func (this *Tag) Post() {
+	result := map[string]interface{}{
+		"success": false,
+	}
+
+	defer func() {
+		this.Data["json"] = result
+		this.ServeJson(this.Data)
+	}()
+
+	if !this.IsAjax() {
+		return
+	}
+
+	switch this.GetString("action") {
+	case "search":
+		prefix := this.GetString("q")
+		if len(prefix) == 0 {
+			return
+		}
+		if names, err := models.SearchTags(prefix, 10); err == nil {
+			result["success"] = true
+			result["data"] = names
+		}
+	}
+}
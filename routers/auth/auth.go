@@ -177,7 +177,7 @@ func (this *Register) Post() error {
 		return err
 	}
 
-	auth.SendRegisterMail(middlewares.Renders, this.Locale, user)
+	auth.SendRegisterMail(middlewares.Renders, user)
 
 	loginRedirect := this.LoginUser(user, false)
 	if loginRedirect == "/" {
@@ -264,7 +264,7 @@ func (this *ForgotRouter) Post() {
 	}
 
 	// send reset password email
-	auth.SendResetPwdMail(this.Locale, &user)
+	auth.SendResetPwdMail(&user)
 
 	this.FlashRedirect("/forgot", 302, "SuccessSend")
 
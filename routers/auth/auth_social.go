@@ -173,7 +173,7 @@ func (this *SocialAuthRouter) Post() {
 	default:
 		if err := auth.RegisterUser(&user, formR.UserName, formR.Email, formR.Password, this.Locale); err == nil {
 
-			auth.SendRegisterMail(middlewares.Renders, this.Locale, &user)
+			auth.SendRegisterMail(middlewares.Renders, &user)
 
 			goto connect
 
@@ -56,7 +56,7 @@ func (this *ProfileRouter) Post() {
 			if this.User.IsActive {
 				this.Data["json"] = false
 			} else {
-				auth.SendActiveMail(this.Locale, &this.User)
+				auth.SendActiveMail(&this.User)
 				this.Data["json"] = true
 			}
 
@@ -112,12 +112,16 @@ func (this *Posts) Get() error {
 		return nil
 	}
 
+	//scheduled posts are listed for the author and moderators
+	withScheduled := this.IsLogin && models.CanViewScheduledPostsOf(&this.User, user.Id)
+	//hidden and merged posts are listed for the author and admins
+	withHidden := this.IsLogin && (this.User.Id == user.Id || this.User.IsAdmin)
+
 	limit := 20
-	nums, _ := models.Count(&models.Post{UserId: user.Id})
+	nums, _ := models.CountPostsByUser(user.Id, withScheduled, withHidden)
 	pager := this.SetPaginator(limit, nums)
 
-	var posts = make([]*models.Post, 0)
-	models.Find(limit, pager.Offset(), &posts)
+	posts, _ := models.FindPostsByUser(user.Id, withScheduled, withHidden, limit, pager.Offset())
 
 	this.Data["TheUserPosts"] = posts
 	return this.Render("user/posts.html", this.Data)
@@ -145,6 +149,55 @@ func (this *Comments) Get() error {
 	return this.Render("user/comments.html", this.Data)
 }
 
+// drafts are only visible to their owner
+type Drafts struct {
+	UserRouter
+}
+
+func (this *Drafts) loadOwner(user *models.User) bool {
+	if this.CheckLoginRedirect() {
+		return true
+	}
+	if this.getUser(user) {
+		return true
+	}
+	if user.Id != this.User.Id {
+		this.NotFound()
+		return true
+	}
+	return false
+}
+
+func (this *Drafts) Get() error {
+	var user models.User
+	if this.loadOwner(&user) {
+		return nil
+	}
+
+	limit := 20
+	nums, _ := models.CountDraftsByUserId(user.Id)
+	pager := this.SetPaginator(limit, nums)
+
+	drafts, _ := models.FindDraftsByUserId(user.Id, limit, pager.Offset())
+	this.Data["TheUserDrafts"] = drafts
+	return this.Render("user/drafts.html", this.Data)
+}
+
+func (this *Drafts) Post() {
+	var user models.User
+	if this.loadOwner(&user) {
+		return
+	}
+
+	if this.GetString("action") == "delete" {
+		id, _ := this.GetInt("id")
+		if draft, err := models.GetDraftById(user.Id, id); err == nil {
+			models.DeleteDraft(user.Id, draft.Type, draft.PostId)
+		}
+	}
+	this.Redirect(user.Link() + "/drafts")
+}
+
 func (this *UserRouter) getFollows(user *models.User, following bool) []map[string]interface{} {
 	var follow models.Follow
 	if following {
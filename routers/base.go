@@ -46,10 +46,18 @@ func Init(t *tango.Tango) {
 
 	t.Get("/category/:slug", new(post.Category))
 	t.Get("/category/:catSlug/:sortSlug", new(post.CateNavs))
+	t.Post("/category/:slug/read", new(post.CategoryRead))
+
+	t.Get("/tags", new(post.Tags))
+	t.Get("/tag/:slug", new(post.Tag))
+	t.Get("/tag/:slug/feed", new(post.TagFeed))
 
 	t.Any("/new", new(post.NewPost))
 	t.Any("/post/:post", new(post.SinglePost))
 	t.Any("/post/:post/edit", new(post.EditPost))
+	t.Any("/post/:post/moderate", new(post.ModeratePost))
+	t.Any("/post/:post/history", new(post.PostHistory))
+	t.Any("/post/:post/comment/:comment/edit", new(post.EditComment))
 
 	t.Get("/notification", new(post.NoticeRouter))
 
@@ -64,6 +72,7 @@ func Init(t *tango.Tango) {
 		g.Get("/followers", new(auth.Followers))
 		g.Get("/follow/topics", new(auth.FollowTopics))
 		g.Get("/favorite/posts", new(auth.FavoritePosts))
+		g.Any("/drafts", new(auth.Drafts))
 		g.Get("", new(auth.Home))
 	})
 
@@ -96,6 +105,9 @@ func Init(t *tango.Tango) {
 		g.Post("/user", new(api.Users))
 		g.Post("/md", new(api.Markdown))
 		g.Post("/post", new(api.Post))
+		g.Post("/draft", new(api.Draft))
+		g.Post("/tag", new(api.Tag))
+		g.Post("/poll", new(api.Poll))
 	})
 
 	// /* Admin Routers */
@@ -154,10 +166,41 @@ func Init(t *tango.Tango) {
 			cg.Any("/:id", new(admin.BulletinAdminEdit))
 			cg.Post("/:id/:action", new(admin.BulletinAdminDelete))
 		})
+
+		g.Group("/menu", func(cg *tango.Group) {
+			cg.Get("", new(admin.MenuAdminList))
+			cg.Any("/new", new(admin.MenuAdminNew))
+			cg.Any("/:id", new(admin.MenuAdminEdit))
+			cg.Post("/:id/:action", new(admin.MenuAdminDelete))
+		})
+
+		g.Group("/tag", func(cg *tango.Group) {
+			cg.Get("", new(admin.TagAdminList))
+			cg.Any("/new", new(admin.TagAdminNew))
+			cg.Any("/:id", new(admin.TagAdminEdit))
+			cg.Post("/:id/:action", new(admin.TagAdminDelete))
+		})
+
+		g.Group("/moderator", func(cg *tango.Group) {
+			cg.Get("", new(admin.ModeratorAdminList))
+			cg.Any("/new", new(admin.ModeratorAdminNew))
+			cg.Any("/:id", new(admin.ModeratorAdminEdit))
+			cg.Post("/:id/:action", new(admin.ModeratorAdminDelete))
+		})
+
+		g.Group("/ipblock", func(cg *tango.Group) {
+			cg.Get("", new(admin.IpBlockAdminList))
+			cg.Any("/new", new(admin.IpBlockAdminNew))
+			cg.Any("/:id", new(admin.IpBlockAdminEdit))
+			cg.Post("/:id/:action", new(admin.IpBlockAdminDelete))
+		})
 	})
 
 	t.Get("/:sortSlug", new(post.Navs))
 	t.Get("/page/:slug", new(page.Show))
+	t.Any("/page/:slug/edit", new(page.EditPage))
+	t.Any("/page/:slug/history", new(page.PageHistory))
+	t.Get("/page/:slug/blame", new(page.PageBlame))
 
 	// /* Robot routers for "robot.txt" */
 	t.Get("/robot.txt", new(base.RobotRouter))
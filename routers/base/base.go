@@ -110,6 +110,10 @@ func (this *BaseRouter) Before() {
 	this.Data["xsrf_token"] = this.XsrfValue
 	this.Data["xsrf_html"] = this.XsrfFormHtml()
 
+	// menus of navbar and footer
+	this.Data["NavbarMenus"] = models.GetMenus(setting.MENU_NAVBAR)
+	this.Data["FooterMenus"] = models.GetMenus(setting.MENU_FOOTER)
+
 	// read unread notifications
 	if this.IsLogin {
 		this.Data["UnreadNotificationCount"] = models.GetUnreadNotificationCount(this.User.Id)
@@ -468,6 +472,41 @@ func (this *BaseRouter) GetInt(k string) (int64, error) {
 	return strconv.ParseInt(this.Req().FormValue(k), 10, 64)
 }
 
+// ContentLangs returns the indexes of the languages to list posts in,
+// nil means all languages.
+// The 'langs' argument like 'en-US,zh-CN' overrides them for feed readers,
+// users choose them in the profile settings, other visitors get the
+// languages of 'Accept-Language'.
+func (this *BaseRouter) ContentLangs() []int {
+	if langs := this.GetString("langs"); len(langs) > 0 {
+		return langIndexes(strings.Split(langs, ","), true)
+	}
+	if this.IsLogin {
+		return langIndexes(this.User.ContentLangList(), false)
+	}
+	return langIndexes(utils.AcceptLanguages(this.Req().Header.Get("Accept-Language")), true)
+}
+
+// langIndexes maps lang names to the indexes of setting.Langs, the primary
+// subtag like 'en' matches all the regional langs when loose is set.
+func langIndexes(names []string, loose bool) []int {
+	var indexes []int
+	seen := make(map[int]bool)
+	for _, name := range names {
+		for i, lang := range setting.Langs {
+			if seen[i] {
+				continue
+			}
+			if strings.EqualFold(name, lang) ||
+				loose && strings.EqualFold(name, strings.SplitN(lang, "-", 2)[0]) {
+				seen[i] = true
+				indexes = append(indexes, i)
+			}
+		}
+	}
+	return indexes
+}
+
 // setLang sets site language version.
 func (this *BaseRouter) setLang() bool {
 	isNeedRedir := false
@@ -510,10 +549,9 @@ func (this *BaseRouter) setLang() bool {
 		}
 	}
 
-	// 4. DefaultLang language is Chinese.
+	// 4. DefaultLang language is set in [i18n] default_lang.
 	if len(lang) == 0 {
-		//lang = "en-US"
-		lang = "zh-CN"
+		lang = i18n.GetLangByIndex(setting.DefaultLang)
 		isNeedRedir = false
 	}
 
@@ -15,30 +15,72 @@
 package page
 
 import (
+	"github.com/lunny/log"
 	"github.com/missdeer/wego/models"
 	"github.com/missdeer/wego/routers/base"
 )
 
-type Show struct {
+// Page Router
+type PageRouter struct {
 	base.BaseRouter
 }
 
-func (this *Show) loadPage(page *models.Page) bool {
-	uri := this.Ctx.Req().RequestURI
+// uri of the page in request path
+func (this *PageRouter) pageUri() string {
+	return "/page/" + this.Params().Get(":slug")
+}
 
-	page, err := models.GetPage(true, uri)
-	if err == nil {
-		this.Data["Page"] = page
-	} else {
+func (this *PageRouter) loadPage(page *models.Page) bool {
+	p, err := models.GetPage(true, this.pageUri())
+	if err != nil {
 		this.NotFound()
+		return true
+	}
+	*page = *p
+	this.Data["Page"] = page
+	this.Data["CanEditPage"] = page.CanEdit(&this.User)
+	this.Data["PageAncestors"] = models.FindPageAncestors(page)
+	return false
+}
+
+// Set the breadcrumbs and the table of contents of the page tree
+func (this *PageRouter) setPageNav(page *models.Page) {
+	ancestors := models.FindPageAncestors(page)
+	this.Data["PageAncestors"] = ancestors
+
+	root := page
+	if len(ancestors) > 0 {
+		root = ancestors[0]
+	}
+	nodes, err := models.FindPageTree(root)
+	if err != nil {
+		log.Error("FindPageTree:", err)
+		return
+	}
+	if len(nodes) > 1 {
+		this.Data["PageTree"] = nodes
 	}
-	return err != nil
+}
+
+type Show struct {
+	PageRouter
 }
 
 func (this *Show) Get() error {
-	page := models.Page{}
-	if this.loadPage(&page) {
+	uri := this.pageUri()
+	page, err := models.GetPage(true, uri)
+	if err != nil {
+		//missing wiki pages are created by the users who can
+		if uri == models.WikiPageUri(this.Params().Get(":slug")) && models.CanCreateWikiPage(&this.User) {
+			page := models.Page{Uri: uri}
+			this.Redirect(page.Link()+"/edit", 302)
+			return nil
+		}
+		this.NotFound()
 		return nil
 	}
+	this.Data["Page"] = page
+	this.Data["CanEditPage"] = page.CanEdit(&this.User)
+	this.setPageNav(page)
 	return this.Render("page/show.html", this.Data)
 }
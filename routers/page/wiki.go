@@ -0,0 +1,276 @@
+package page
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/lunny/log"
+	"github.com/missdeer/wego/models"
+	"github.com/missdeer/wego/modules/page"
+	"github.com/missdeer/wego/modules/utils"
+	"github.com/missdeer/wego/setting"
+)
+
+// Edit or create wiki page
+type EditPage struct {
+	PageRouter
+}
+
+// Load the page to edit, the page is new if it doesn't exist yet
+func (this *EditPage) loadEditPage(pageMd *models.Page) bool {
+	if this.CheckActiveRedirect() {
+		return true
+	}
+
+	uri := this.pageUri()
+	if p, err := models.GetPage(true, uri); err == nil {
+		*pageMd = *p
+		if !pageMd.CanEdit(&this.User) {
+			this.NotFound()
+			return true
+		}
+	} else {
+		//unpublished pages are not replaced by wiki pages
+		if _, err := models.GetPage(false, uri); err == nil ||
+			uri != models.WikiPageUri(this.Params().Get(":slug")) || !models.CanCreateWikiPage(&this.User) {
+			this.NotFound()
+			return true
+		}
+		pageMd.Uri = uri
+		pageMd.IsWiki = true
+	}
+	this.Data["Page"] = pageMd
+	return false
+}
+
+func (this *EditPage) Get() {
+	var pageMd models.Page
+	if this.loadEditPage(&pageMd) {
+		return
+	}
+
+	form := page.WikiForm{Create: pageMd.Id == 0, Uri: pageMd.Uri}
+	if form.Create {
+		form.Title = this.GetString("title")
+		if len(form.Title) == 0 {
+			form.Title = strings.Replace(this.Params().Get(":slug"), "-", " ", -1)
+		}
+	} else {
+		form.SetFromPage(&pageMd)
+	}
+	this.SetFormSets(&form)
+	this.Render("page/edit.html", this.Data)
+}
+
+func (this *EditPage) Post() {
+	var pageMd models.Page
+	if this.loadEditPage(&pageMd) {
+		return
+	}
+
+	form := page.WikiForm{Create: pageMd.Id == 0, Uri: pageMd.Uri}
+	if !form.Create {
+		form.SetFromPage(&pageMd)
+	}
+	// the form once is checked with the form, so a page isn't created twice
+	// by a double submit
+	if !this.ValidFormSets(&form) {
+		this.Render("page/edit.html", this.Data)
+		return
+	}
+
+	var err error
+	if form.Create {
+		err = form.CreatePage(&pageMd, &this.User)
+	} else {
+		err = form.UpdatePage(&pageMd, &this.User)
+	}
+	if err == models.ErrPageExist {
+		// created by another request at the same time
+		this.Redirect(pageMd.Link(), 302)
+		return
+	}
+	if err != nil {
+		log.Error("SavePage:", err)
+		this.Render("page/edit.html", this.Data)
+		return
+	}
+	this.Redirect(pageMd.Link(), 302)
+}
+
+// revision with the changes from previous one
+type PageRevisionDiff struct {
+	Revision models.PageRevision
+	Number   int
+	Lines    []utils.DiffLine
+	TooLarge bool
+}
+
+// revisions shown on a page of history
+const pageRevisionsPerPage = 10
+
+// Edit history of page
+type PageHistory struct {
+	PageRouter
+}
+
+func (this *PageHistory) Get() {
+	var pageMd models.Page
+	if this.loadPage(&pageMd) {
+		return
+	}
+
+	cnt, err := models.CountPageRevisions(pageMd.Id)
+	if err != nil {
+		log.Error("CountPageRevisions:", err)
+	}
+	pager := this.SetPaginator(pageRevisionsPerPage, cnt)
+
+	// newest first, the one more revision is the base of the last diff
+	revisions, err := models.FindPageRevisionsDesc(pageMd.Id, pageRevisionsPerPage, pager.Offset())
+	if err != nil {
+		log.Error("FindPageRevisionsDesc:", err)
+	}
+
+	diffs := make([]*PageRevisionDiff, 0, len(revisions))
+	for i := 0; i < len(revisions) && i < pageRevisionsPerPage; i++ {
+		var prev models.PageRevision
+		if i+1 < len(revisions) {
+			prev = revisions[i+1]
+		}
+		diff := &PageRevisionDiff{
+			Revision: revisions[i],
+			Number:   int(cnt) - pager.Offset() - i,
+			TooLarge: utils.DiffTooLarge(prev.Content, revisions[i].Content),
+		}
+		if !diff.TooLarge {
+			diff.Lines = utils.DiffLines(prev.Content, revisions[i].Content)
+		}
+		diffs = append(diffs, diff)
+	}
+	this.Data["Revisions"] = diffs
+
+	this.Render("page/history.html", this.Data)
+}
+
+// revert to the revision
+func (this *PageHistory) Post() {
+	if this.CheckActiveRedirect() {
+		return
+	}
+
+	var pageMd models.Page
+	if this.loadPage(&pageMd) {
+		return
+	}
+	if !pageMd.CanEdit(&this.User) {
+		this.NotFound()
+		return
+	}
+
+	if this.FormOnceNotMatch() {
+		return
+	}
+
+	revisionId, _ := this.GetInt("revision")
+	revision, err := models.GetPageRevision(pageMd.Id, revisionId)
+	if err != nil {
+		this.NotFound()
+		return
+	}
+
+	// revision number of the reverted one
+	number, err := models.PageRevisionNumber(revision)
+	if err != nil {
+		log.Error("PageRevisionNumber:", err)
+	}
+
+	form := page.WikiForm{
+		Content: revision.Content,
+		Reason:  this.Tr("page.revision_revert_reason", number),
+	}
+	if err := form.UpdatePage(&pageMd, &this.User); err != nil {
+		log.Error("RevertPage:", err)
+		this.Redirect(pageMd.Link()+"/history", 302)
+		return
+	}
+	this.FlashRedirect(pageMd.Link()+"/history", 302, "RevertSuccess")
+}
+
+// line of page with the revision which added it
+type PageBlameLine struct {
+	utils.BlameLine
+	Revision *models.PageRevision
+	Number   int
+}
+
+// Blame of page, every line with the revision which added it
+type PageBlame struct {
+	PageRouter
+}
+
+func (this *PageBlame) Get() {
+	var pageMd models.Page
+	if this.loadPage(&pageMd) {
+		return
+	}
+
+	// the contents are loaded when the blame isn't cached
+	revisions, err := models.FindPageRevisionsInfo(pageMd.Id)
+	if err != nil {
+		log.Error("FindPageRevisionsInfo:", err)
+	}
+	//pages never edited have the original only
+	if len(revisions) == 0 {
+		revisions = append(revisions, models.PageRevision{
+			PageId:  pageMd.Id,
+			UserId:  pageMd.UserId,
+			Title:   pageMd.Title,
+			Content: pageMd.Content,
+			Created: pageMd.Created,
+		})
+	}
+
+	blames := pageBlames(pageMd.Id, revisions)
+	lines := make([]*PageBlameLine, 0, len(blames))
+	for i, blame := range blames {
+		line := &PageBlameLine{BlameLine: blame, Number: blame.Index + 1}
+		line.Revision = &revisions[blame.Index]
+		//the revision is shown at the first of its lines only
+		if i > 0 && blames[i-1].Index == blame.Index {
+			line.Revision = nil
+		}
+		lines = append(lines, line)
+	}
+	this.Data["BlameLines"] = lines
+
+	this.Render("page/blame.html", this.Data)
+}
+
+// blame of the revisions, cached by the newest revision
+func pageBlames(pageId int64, revisions []models.PageRevision) []utils.BlameLine {
+	last := revisions[len(revisions)-1]
+	key := fmt.Sprintf("PageBlame.%d.%d", pageId, last.Id)
+	if blames, ok := setting.Cache.Get(key).([]utils.BlameLine); ok {
+		return blames
+	}
+
+	texts := []string{last.Content}
+	if last.Id > 0 {
+		contents, err := models.FindPageRevisions(pageId)
+		if err != nil {
+			log.Error("FindPageRevisions:", err)
+			return nil
+		}
+		texts = texts[:0]
+		for _, revision := range contents {
+			// the revisions added after the list are left out
+			if revision.Id <= last.Id {
+				texts = append(texts, revision.Content)
+			}
+		}
+	}
+	blames := utils.BlameLines(texts)
+	setting.Cache.Put(key, blames, 24*60*60)
+	return blames
+}
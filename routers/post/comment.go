@@ -0,0 +1,109 @@
+package post
+
+import (
+	"strconv"
+
+	"github.com/lunny/log"
+	"github.com/missdeer/wego/models"
+	"github.com/missdeer/wego/modules/post"
+)
+
+// Edit and delete comment by its author
+type EditComment struct {
+	PostRouter
+	perm models.Permission
+}
+
+// Author can edit the comment within the grace window and delete it at any time,
+// moderators who can edit comments are not limited by the window.
+func (this *EditComment) loadEditComment(postMd *models.Post, edit bool) *models.Comment {
+	if this.CheckActiveRedirect() {
+		return nil
+	}
+
+	if this.loadPost(postMd, nil) {
+		return nil
+	}
+
+	id, _ := strconv.ParseInt(this.Params().Get(":comment"), 10, 64)
+	comment, err := models.GetCommentById(id)
+	if err != nil || comment.PostId != postMd.Id || comment.IsDelete {
+		this.NotFound()
+		return nil
+	}
+
+	this.perm = this.loadPermission(postMd)
+	if this.perm.CanEditComment() {
+		return comment
+	}
+
+	if comment.UserId != this.User.Id {
+		this.NotFound()
+		return nil
+	}
+
+	if postMd.IsLock {
+		this.FlashRedirect(postMd.Path(), 302, "PostLocked")
+		return nil
+	}
+
+	if edit && !comment.InEditWindow() {
+		this.FlashRedirect(postMd.Path(), 302, "CanNotEditComment")
+		return nil
+	}
+	return comment
+}
+
+func (this *EditComment) Get() {
+	var postMd models.Post
+	comment := this.loadEditComment(&postMd, true)
+	if comment == nil {
+		return
+	}
+
+	form := post.CommentForm{}
+	form.SetFromComment(comment)
+	this.SetFormSets(&form)
+	this.Data["Comment"] = comment
+	this.Render("post/comment_edit.html", this.Data)
+}
+
+func (this *EditComment) Post() {
+	var postMd models.Post
+	comment := this.loadEditComment(&postMd, this.GetString("action") != "delete")
+	if comment == nil {
+		return
+	}
+
+	if this.GetString("action") == "delete" {
+		if this.FormOnceNotMatch() {
+			this.Redirect(postMd.Path(), 302)
+			return
+		}
+		if err := models.DeleteComment(comment); err != nil {
+			log.Error("DeleteComment error:", err)
+		}
+		this.FlashRedirect(postMd.Path(), 302, "CommentDeleted")
+		return
+	}
+
+	this.Data["Comment"] = comment
+	form := post.CommentForm{}
+	if !this.ValidFormSets(&form) {
+		this.Render("post/comment_edit.html", this.Data)
+		return
+	}
+
+	if err := form.UpdateComment(comment); err != nil {
+		log.Error("UpdateComment error:", err)
+		this.Render("post/comment_edit.html", this.Data)
+		return
+	}
+
+	// notifications are sent on behalf of the comment author
+	if author := comment.User(); author != nil {
+		post.UpdateCommentMentions(author, &postMd, comment)
+	}
+	this.JsStorage("deleteKey", "post/comment/edit")
+	this.Redirect(postMd.FloorLink(comment.Floor))
+}
@@ -49,7 +49,7 @@ func (this *PostListRouter) setTopicsOfCategory(topics *[]models.Topic, category
 
 //Get new best posts
 func (this *PostListRouter) setNewBestPosts(posts *[]models.Post) {
-	err := models.NewBestPostsByExample(posts, &models.Post{})
+	err := models.NewBestPostsByExample(posts, &models.Post{}, this.ContentLangs())
 	if err != nil {
 		this.Result = err
 		return
@@ -60,7 +60,7 @@ func (this *PostListRouter) setNewBestPosts(posts *[]models.Post) {
 
 //Get new best posts by category
 func (this *PostListRouter) setNewBestPostsOfCategory(posts *[]models.Post, cat *models.Category) {
-	err := models.NewBestPostsByExample(posts, &models.Post{CategoryId: cat.Id})
+	err := models.NewBestPostsByExample(posts, &models.Post{CategoryId: cat.Id}, this.ContentLangs())
 	if err != nil {
 		this.Result = err
 		return
@@ -71,7 +71,7 @@ func (this *PostListRouter) setNewBestPostsOfCategory(posts *[]models.Post, cat
 
 //Get new best posts by topic
 func (this *PostListRouter) setNewBestPostsOfTopic(posts *[]models.Post, topic *models.Topic) {
-	err := models.NewBestPostsByExample(posts, &models.Post{TopicId: topic.Id})
+	err := models.NewBestPostsByExample(posts, &models.Post{TopicId: topic.Id}, this.ContentLangs())
 	if err != nil {
 		this.Result = err
 		return
@@ -82,7 +82,7 @@ func (this *PostListRouter) setNewBestPostsOfTopic(posts *[]models.Post, topic *
 
 //Get most replys posts
 func (this *PostListRouter) setMostReplysPosts(posts *[]models.Post) {
-	err := models.MostReplysPostsByExample(posts, &models.Post{})
+	err := models.MostReplysPostsByExample(posts, &models.Post{}, this.ContentLangs())
 	if err != nil {
 		this.Result = err
 		return
@@ -92,7 +92,7 @@ func (this *PostListRouter) setMostReplysPosts(posts *[]models.Post) {
 
 //Get most replys posts of category
 func (this *PostListRouter) setMostReplysPostsOfCategory(posts *[]models.Post, cat *models.Category) {
-	err := models.MostReplysPostsByExample(posts, &models.Post{CategoryId: cat.Id})
+	err := models.MostReplysPostsByExample(posts, &models.Post{CategoryId: cat.Id}, this.ContentLangs())
 	if err != nil {
 		this.Result = err
 		return
@@ -102,7 +102,7 @@ func (this *PostListRouter) setMostReplysPostsOfCategory(posts *[]models.Post, c
 
 //Get most replys post of topic
 func (this *PostListRouter) setMostReplysPostsOfTopic(posts *[]models.Post, topic *models.Topic) {
-	err := models.MostReplysPostsByExample(posts, &models.Post{TopicId: topic.Id})
+	err := models.MostReplysPostsByExample(posts, &models.Post{TopicId: topic.Id}, this.ContentLangs())
 	if err != nil {
 		this.Result = err
 		return
@@ -110,6 +110,37 @@ func (this *PostListRouter) setMostReplysPostsOfTopic(posts *[]models.Post, topi
 	this.Data["MostReplysPosts"] = posts
 }
 
+//Get the tag to filter the posts by, synonyms are resolved to their tags
+func (this *PostListRouter) tagFilter() int64 {
+	slug := this.GetString("tag")
+	if len(slug) == 0 {
+		return 0
+	}
+
+	tag, err := models.GetTagBySlug(slug)
+	if err != nil {
+		return 0
+	}
+	if synonym := tag.Synonym(); synonym != nil {
+		tag = synonym
+	}
+	this.Data["TagFilter"] = tag
+	return tag.Id
+}
+
+//Get the read states of posts for current user
+func (this *PostListRouter) setReadStates(posts []models.Post) {
+	if !this.IsLogin {
+		return
+	}
+	states, err := models.FindReadStates(this.User.Id, posts)
+	if err != nil {
+		log.Error("FindReadStates error:", err)
+		return
+	}
+	this.Data["ReadStates"] = states
+}
+
 //Get sidebar bulletin information
 func (this *PostListRouter) setSidebarBuilletinInfo() {
 	bulletins, err := models.FindBulletins()
@@ -148,18 +179,21 @@ type Home struct {
 
 func (h *Home) Get() error {
 	//get posts by Created datetime desc order
-	cnt, err := models.CountByExample(&models.Post{})
+	tagId := h.tagFilter()
+	cnt, err := models.CountPostsByTag(&models.Post{}, tagId, h.ContentLangs())
 	if err != nil {
 		return err
 	}
 
 	pager := h.SetPaginator(setting.PostCountPerPage, cnt)
-	posts, err := models.FindPosts(setting.PostCountPerPage, pager.Offset())
+	posts, err := models.FindPostsByTag(&models.Post{}, tagId, h.ContentLangs(), setting.PostCountPerPage, pager.Offset())
 	if err != nil {
 		return err
 	}
 
 	h.Data["Posts"] = posts
+	h.setReadStates(posts)
+	h.Data["StickyLevel"] = setting.STICKY_GLOBAL
 
 	//top nav bar data
 	var cats []models.Category
@@ -185,19 +219,27 @@ type Navs struct {
 
 func (this *Navs) Get() error {
 	sortSlug := this.Params().Get(":sortSlug")
+	//Q&A listings are only for the categories
+	if models.IsQuestionSort(sortSlug) {
+		this.NotFound()
+		return nil
+	}
 
-	cnt, err := models.CountByExample(&models.Post{})
+	tagId := this.tagFilter()
+	cnt, err := models.CountPostsByTag(&models.Post{}, tagId, this.ContentLangs())
 	if err != nil {
 		return err
 	}
 
 	pager := this.SetPaginator(setting.PostCountPerPage, cnt)
-	posts, err := models.RecentPosts(sortSlug, setting.PostCountPerPage, pager.Offset())
+	posts, err := models.RecentPostsByTag(sortSlug, &models.Post{}, tagId, this.ContentLangs(), setting.PostCountPerPage, pager.Offset())
 	if err != nil {
 		return err
 	}
 
 	this.Data["Posts"] = posts
+	this.setReadStates(posts)
+	this.Data["StickyLevel"] = setting.STICKY_GLOBAL
 
 	//top nav bar data
 	var cats []models.Category
@@ -228,19 +270,22 @@ func (this *Category) Get() error {
 	}
 
 	//get posts by category slug, order by Created desc
-	cnt, err := models.CountByExample(&models.Post{CategoryId: cat.Id})
+	tagId := this.tagFilter()
+	cnt, err := models.CountPostsByTag(&models.Post{CategoryId: cat.Id}, tagId, this.ContentLangs())
 	if err != nil {
 		return err
 	}
 
 	pager := this.SetPaginator(setting.PostCountPerPage, cnt)
-	posts, err := models.RecentPosts("hot", setting.PostCountPerPage, pager.Offset())
+	posts, err := models.RecentPostsByTag("hot", &models.Post{CategoryId: cat.Id}, tagId, this.ContentLangs(), setting.PostCountPerPage, pager.Offset())
 	if err != nil {
 		return err
 	}
 
 	this.Data["Category"] = cat
 	this.Data["Posts"] = posts
+	this.setReadStates(posts)
+	this.Data["StickyLevel"] = setting.STICKY_CATEGORY
 
 	//top nav bar data
 	var cats []models.Category
@@ -271,20 +316,27 @@ func (this *CateNavs) Get() error {
 	if err != nil {
 		return err
 	}
+	if models.IsQuestionSort(sortSlug) && !cat.IsQuestion {
+		this.NotFound()
+		return nil
+	}
 
-	cnt, err := models.CountByExample(&models.Post{CategoryId: cat.Id})
+	tagId := this.tagFilter()
+	cnt, err := models.CountPostsByTag(&models.Post{CategoryId: cat.Id}, tagId, this.ContentLangs())
 	if err != nil {
 		return err
 	}
 
 	pager := this.SetPaginator(setting.PostCountPerPage, cnt)
-	posts, err := models.RecentPosts(sortSlug, setting.PostCountPerPage, pager.Offset())
+	posts, err := models.RecentPostsByTag(sortSlug, &models.Post{CategoryId: cat.Id}, tagId, this.ContentLangs(), setting.PostCountPerPage, pager.Offset())
 	if err != nil {
 		return err
 	}
 
 	this.Data["Category"] = cat
 	this.Data["Posts"] = posts
+	this.setReadStates(posts)
+	this.Data["StickyLevel"] = setting.STICKY_CATEGORY
 
 	//top nav bar data
 	var cats []models.Category
@@ -323,18 +375,21 @@ func (this *Topic) Get() error {
 	}
 
 	//get posts by topic
-	cnt, err := models.CountByExample(&models.Post{TopicId: topic.Id})
+	tagId := this.tagFilter()
+	cnt, err := models.CountPostsByTag(&models.Post{TopicId: topic.Id}, tagId, this.ContentLangs())
 	if err != nil {
 		return err
 	}
 
 	pager := this.SetPaginator(setting.PostCountPerPage, cnt)
-	posts, err := models.FindPosts(setting.PostCountPerPage, pager.Offset())
+	posts, err := models.FindPostsByTag(&models.Post{TopicId: topic.Id}, tagId, this.ContentLangs(), setting.PostCountPerPage, pager.Offset())
 	if err != nil {
 		return err
 	}
 
 	this.Data["Posts"] = posts
+	this.setReadStates(posts)
+	this.Data["StickyLevel"] = setting.STICKY_TOPIC
 	this.Data["Topic"] = &topic
 	this.Data["Category"] = &category
 
@@ -449,6 +504,13 @@ func (this *NewPost) Get() error {
 		}
 	}
 
+	//restore the autosaved draft
+	if draft, err := models.GetDraft(this.User.Id, setting.DRAFT_TYPE_POST, 0); err == nil {
+		form.Title = draft.Title
+		form.Content = draft.Content
+		this.Data["Draft"] = draft
+	}
+
 	this.SetFormSets(&form)
 	return this.Render("post/new.html", this.Data)
 }
@@ -505,6 +567,7 @@ func (this *NewPost) Post() error {
 	var post models.Post
 	if err := form.SavePost(&post, &this.User); err == nil {
 		this.JsStorage("deleteKey", "post/new")
+		models.DeleteDraft(this.User.Id, setting.DRAFT_TYPE_POST, 0)
 		this.Redirect(post.Link())
 		return nil
 	}
@@ -541,16 +604,101 @@ func (this *PostRouter) loadPost(post *models.Post, user *models.User) bool {
 	return false
 }
 
+//Get moderation permissions of current user on the post
+func (this *PostRouter) loadPermission(post *models.Post) models.Permission {
+	var perm models.Permission
+	if this.IsLogin {
+		perm = models.GetPostPermission(&this.User, post)
+	}
+	this.Data["Perm"] = perm
+	return perm
+}
+
+//Hidden and scheduled posts are only visible to the author and moderators
+func (this *PostRouter) canViewPost(post *models.Post, perm models.Permission) bool {
+	return models.CanViewPost(&this.User, post, perm)
+}
+
+//Load a page of comments of post, sorted by floor or by score
 func (this *PostRouter) loadComments(post *models.Post, comments *[]*models.Comment) {
-	err := models.GetCommentsByPostId(comments, post.Id)
-	if err == nil {
-		this.Data["Comments"] = *comments
-		this.Data["CommentsNum"] = len(*comments)
+	cnt, err := models.CountCommentsByPostId(post.Id)
+	if err != nil {
+		log.Error("loadComments error:", err)
+		return
+	}
+	pager := this.SetPaginator(setting.CommentCountPerPage, cnt)
+
+	if this.GetString("sort") == "score" {
+		this.Data["CommentSort"] = "score"
+		*comments, err = models.FindCommentsByPostIdOrderByScore(post.Id, setting.CommentCountPerPage, pager.Offset())
 	} else {
+		*comments, err = models.FindCommentsByPostId(post.Id, setting.CommentCountPerPage, pager.Offset())
+	}
+	if err != nil {
 		log.Error("loadComments error:", err)
+		return
+	}
+
+	//index of comments for the replied context, parents may be on other pages
+	commentsById := make(map[int64]*models.Comment, len(*comments))
+	for _, comment := range *comments {
+		commentsById[comment.Id] = comment
+	}
+	var parentIds []int64
+	for _, comment := range *comments {
+		if _, ok := commentsById[comment.ParentId]; comment.ParentId > 0 && !ok {
+			parentIds = append(parentIds, comment.ParentId)
+		}
+	}
+	if len(parentIds) > 0 {
+		if parents, err := models.FindCommentsByIds(parentIds); err == nil {
+			for _, parent := range parents {
+				commentsById[parent.Id] = parent
+			}
+		}
+	}
+
+	this.Data["Comments"] = *comments
+	this.Data["CommentsById"] = commentsById
+	this.Data["CommentsNum"] = cnt
+	this.Data["CommentCountPerPage"] = setting.CommentCountPerPage
+}
+
+//Load the accepted answer of post in Q&A category,
+//the author and moderators can accept an answer
+func (this *PostRouter) loadQuestion(post *models.Post, perm models.Permission) {
+	cat := post.Category()
+	if cat == nil || !cat.IsQuestion {
+		return
+	}
+	this.Data["IsQuestion"] = true
+	this.Data["CanAccept"] = perm.CanEditPost() || (this.IsLogin && post.UserId == this.User.Id)
+	if answer := post.Answer(); answer != nil && !answer.IsHide {
+		this.Data["Answer"] = answer
 	}
 }
 
+//Load the poll of post with the choices of current user
+func (this *PostRouter) loadPoll(post *models.Post) {
+	poll := post.Poll()
+	if poll == nil {
+		return
+	}
+
+	choices := make(map[int64]bool)
+	if this.IsLogin {
+		if vote, err := models.GetPollVote(poll.Id, this.User.Id); err == nil {
+			for _, id := range vote.OptionIds() {
+				choices[id] = true
+			}
+		}
+	}
+	this.Data["Poll"] = poll
+	this.Data["PollChoices"] = choices
+	this.Data["PollVoted"] = len(choices) > 0
+	this.Data["PollShowResults"] = poll.CanShowResults(len(choices) > 0)
+}
+
 type SinglePost struct {
 	PostRouter
 }
@@ -562,19 +710,72 @@ func (this *SinglePost) Get() error {
 		return nil
 	}
 
+	//moved or merged post
+	if postMd.RedirectId > 0 {
+		target := models.Post{Id: postMd.RedirectId}
+		this.Redirect(target.Link(), 301)
+		return nil
+	}
+
+	perm := this.loadPermission(&postMd)
+	if !this.canViewPost(&postMd, perm) {
+		this.NotFound()
+		return nil
+	}
+
+	//deep link to a floor resolves to the page which lists it
+	if floor := this.GetString("floor"); len(floor) > 0 {
+		if floor == "last" {
+			this.Redirect(postMd.LatestLink(), 302)
+			return nil
+		}
+		if n, err := strconv.Atoi(floor); err == nil {
+			this.Redirect(postMd.FloorLink(n), 302)
+			return nil
+		}
+	}
+
 	var comments []*models.Comment
 	this.loadComments(&postMd, &comments)
+	this.loadQuestion(&postMd, perm)
+	this.loadPoll(&postMd)
 
 	//mark all notification as read
 	if this.IsLogin {
 		models.MarkNortificationAsRead(this.User.Id, postMd.Id)
 	}
 
+	//remember the last floor read, pages sorted by score are not read in order
+	if this.IsLogin && this.GetString("sort") != "score" {
+		var floor int
+		for _, comment := range comments {
+			if comment.Floor > floor {
+				floor = comment.Floor
+			}
+		}
+		if err := models.MarkPostRead(this.User.Id, postMd.Id, floor); err != nil {
+			log.Error("MarkPostRead error:", err)
+		}
+	}
+
+	//votes of current user
+	commentIds := make([]int64, 0, len(comments))
+	for _, comment := range comments {
+		commentIds = append(commentIds, comment.Id)
+	}
+	this.Data["PostVote"] = models.FindUserVotes(this.User.Id, setting.VOTE_TARGET_POST, []int64{postMd.Id})[postMd.Id]
+	this.Data["CommentVotes"] = models.FindUserVotes(this.User.Id, setting.VOTE_TARGET_COMMENT, commentIds)
+
 	//check whether this post is favorited
 	isPostFav, _ := models.IsPostFavorite(postMd.Id, int64(this.User.Id))
 	this.Data["IsPostFav"] = isPostFav
 
 	form := post.CommentForm{}
+	if this.IsLogin {
+		if draft, err := models.GetDraft(this.User.Id, setting.DRAFT_TYPE_COMMENT, postMd.Id); err == nil {
+			form.Message = draft.Content
+		}
+	}
 	this.SetFormSets(&form)
 	//increment PageViewCount
 
@@ -593,6 +794,18 @@ func (this *SinglePost) Post() {
 		return
 	}
 
+	perm := this.loadPermission(&postMd)
+	if postMd.RedirectId > 0 || !this.canViewPost(&postMd, perm) {
+		this.NotFound()
+		return
+	}
+
+	//only moderators can reply a locked post
+	if postMd.IsLock && !perm.CanLockPost() {
+		this.FlashRedirect(postMd.Path(), 302, "PostLocked")
+		return
+	}
+
 	var redir bool
 
 	defer func() {
@@ -611,7 +824,8 @@ func (this *SinglePost) Post() {
 	if err := form.SaveComment(&comment, &this.User, &postMd); err == nil {
 		post.FilterCommentMentions(&this.User, &postMd, &comment)
 		this.JsStorage("deleteKey", "post/comment")
-		this.Redirect(postMd.Link(), 302)
+		models.DeleteDraft(this.User.Id, setting.DRAFT_TYPE_COMMENT, postMd.Id)
+		this.Redirect(postMd.FloorLink(comment.Floor), 302)
 		redir = true
 
 		post.PostReplysCount(&postMd)
@@ -621,6 +835,57 @@ func (this *SinglePost) Post() {
 
 type EditPost struct {
 	PostRouter
+	perm models.Permission
+}
+
+//Author can edit the post until it's locked by CanEdit,
+//moderators can always edit the posts in their scope
+func (this *EditPost) loadEditPost(post *models.Post) bool {
+	if this.loadPost(post, nil) {
+		return true
+	}
+
+	this.perm = this.loadPermission(post)
+	if this.perm.CanEditPost() {
+		return false
+	}
+
+	if post.UserId != this.User.Id {
+		this.NotFound()
+		return true
+	}
+
+	if !post.CanEdit {
+		this.FlashRedirect(post.Path(), 302, "CanNotEditPost")
+		return true
+	}
+	return false
+}
+
+//Restore the autosaved draft of edit, a draft older than the post
+//is only restored on request, otherwise it's kept and reported as conflict
+func (this *EditPost) loadEditDraft(postMd *models.Post, form *post.PostForm) {
+	this.Data["DraftBase"] = postMd.Updated.Unix()
+
+	draft, err := models.GetDraft(this.User.Id, setting.DRAFT_TYPE_EDIT, postMd.Id)
+	if err != nil {
+		return
+	}
+
+	if draft.IsConflict() {
+		if this.GetString("draft") != "restore" {
+			this.Data["DraftConflict"] = draft
+			return
+		}
+		draft.Base = postMd.Updated
+		models.UpdateById(draft.Id, draft, "base")
+	}
+
+	if len(draft.Title) > 0 {
+		form.Title = draft.Title
+	}
+	form.Content = draft.Content
+	this.Data["Draft"] = draft
 }
 
 func (this *EditPost) Get() {
@@ -629,35 +894,28 @@ func (this *EditPost) Get() {
 	}
 
 	var postMd models.Post
-	if this.loadPost(&postMd, &this.User) {
+	if this.loadEditPost(&postMd) {
 		return
 	}
 
-	if !postMd.CanEdit {
-		this.Redirect(postMd.Link())
-		return
-	}
 	form := post.PostForm{}
 	form.SetFromPost(&postMd)
 	models.FindTopics(&form.Topics)
+	this.loadEditDraft(&postMd, &form)
 	this.SetFormSets(&form)
 	this.Render("post/edit.html", this.Data)
 }
 
-func (this *PostRouter) Post() {
+func (this *EditPost) Post() {
 	if this.CheckActiveRedirect() {
 		return
 	}
 
 	var postMd models.Post
-	if this.loadPost(&postMd, &this.User) {
+	if this.loadEditPost(&postMd) {
 		return
 	}
 
-	if !postMd.CanEdit {
-		this.FlashRedirect(postMd.Path(), 302, "CanNotEditPost")
-	}
-
 	form := post.PostForm{}
 	form.SetFromPost(&postMd)
 	models.FindTopics(&form.Topics)
@@ -665,10 +923,37 @@ func (this *PostRouter) Post() {
 		return
 	}
 
+	//only author and moderators who can move post are allowed to change the topic
+	if postMd.UserId != this.User.Id && !this.perm.CanMovePost() {
+		form.Topic = postMd.TopicId
+	}
+
 	if err := form.UpdatePost(&postMd, &this.User); err == nil {
 		this.JsStorage("deleteKey", "post/edit")
+		models.DeleteDraft(this.User.Id, setting.DRAFT_TYPE_EDIT, postMd.Id)
 		this.Redirect(postMd.Link())
 		return
 	}
 	this.Render("post/edit.html", this.Data)
 }
+
+//Mark all posts of category as read
+type CategoryRead struct {
+	PostListRouter
+}
+
+func (this *CategoryRead) Post() {
+	if this.CheckActiveRedirect() {
+		return
+	}
+
+	cat, err := models.GetCategoryBySlug(this.Params().Get(":slug"))
+	if err != nil {
+		this.NotFound()
+		return
+	}
+	if err := models.MarkCategoryRead(this.User.Id, cat.Id); err != nil {
+		log.Error("MarkCategoryRead error:", err)
+	}
+	this.Redirect(cat.Link(), 302)
+}
@@ -0,0 +1,131 @@
+package post
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/lunny/log"
+	"github.com/missdeer/wego/models"
+	"github.com/missdeer/wego/modules/utils"
+)
+
+// revision with the changes from previous one
+type PostRevisionDiff struct {
+	Revision models.PostRevision
+	Number   int
+	OldTitle string
+	Lines    []utils.DiffLine
+	TooLarge bool
+}
+
+func (d *PostRevisionDiff) TitleChanged() bool {
+	return d.Number > 1 && d.OldTitle != d.Revision.Title
+}
+
+// revisions shown on a page of history
+const postRevisionsPerPage = 10
+
+// Edit history of post
+type PostHistory struct {
+	PostRouter
+}
+
+func (this *PostHistory) historyPath(post *models.Post) string {
+	return fmt.Sprintf("%s/history", post.Path())
+}
+
+func (this *PostHistory) Get() {
+	var postMd models.Post
+	if this.loadPost(&postMd, nil) {
+		return
+	}
+
+	perm := this.loadPermission(&postMd)
+	if postMd.RedirectId > 0 || !this.canViewPost(&postMd, perm) {
+		this.NotFound()
+		return
+	}
+
+	cnt, err := models.CountPostRevisions(postMd.Id)
+	if err != nil {
+		log.Error("CountPostRevisions:", err)
+	}
+	pager := this.SetPaginator(postRevisionsPerPage, cnt)
+
+	// newest first, the one more revision is the base of the last diff
+	revisions, err := models.FindPostRevisionsDesc(postMd.Id, postRevisionsPerPage, pager.Offset())
+	if err != nil {
+		log.Error("FindPostRevisionsDesc:", err)
+	}
+
+	diffs := make([]*PostRevisionDiff, 0, len(revisions))
+	for i := 0; i < len(revisions) && i < postRevisionsPerPage; i++ {
+		var prev models.PostRevision
+		if i+1 < len(revisions) {
+			prev = revisions[i+1]
+		}
+		diff := &PostRevisionDiff{
+			Revision: revisions[i],
+			Number:   int(cnt) - pager.Offset() - i,
+			OldTitle: prev.Title,
+			TooLarge: utils.DiffTooLarge(prev.Content, revisions[i].Content),
+		}
+		if !diff.TooLarge {
+			diff.Lines = utils.DiffLines(prev.Content, revisions[i].Content)
+		}
+		diffs = append(diffs, diff)
+	}
+	this.Data["Revisions"] = diffs
+
+	this.Render("post/history.html", this.Data)
+}
+
+// roll back to the revision
+func (this *PostHistory) Post() {
+	if this.CheckActiveRedirect() {
+		return
+	}
+
+	var postMd models.Post
+	if this.loadPost(&postMd, nil) {
+		return
+	}
+
+	perm := this.loadPermission(&postMd)
+	if postMd.RedirectId > 0 || !perm.CanEditPost() {
+		this.NotFound()
+		return
+	}
+
+	if this.FormOnceNotMatch() {
+		return
+	}
+
+	revisionId, _ := this.GetInt("revision")
+	revision, err := models.GetPostRevision(postMd.Id, revisionId)
+	if err != nil {
+		this.NotFound()
+		return
+	}
+
+	// revision number of the rolled back one
+	number, err := models.PostRevisionNumber(revision)
+	if err != nil {
+		log.Error("PostRevisionNumber:", err)
+	}
+
+	original := postMd
+	postMd.Title = revision.Title
+	postMd.Content = revision.Content
+	postMd.ContentCache = utils.RenderMarkdown(revision.Content)
+	postMd.LastAuthorId = this.User.Id
+	postMd.Updated = time.Now()
+	reason := this.Tr("post.revision_rollback_reason", number)
+	if err := models.UpdatePostRevision(&original, &postMd, this.User.Id, reason,
+		"title", "content", "content_cache", "last_author_id", "updated"); err != nil {
+		log.Error("RollbackPost:", err)
+		this.Redirect(this.historyPath(&postMd), 302)
+		return
+	}
+	this.FlashRedirect(this.historyPath(&postMd), 302, "RollbackSuccess")
+}
@@ -0,0 +1,106 @@
+package post
+
+import (
+	"github.com/lunny/log"
+	"github.com/missdeer/wego/models"
+	"github.com/missdeer/wego/modules/post"
+	"github.com/missdeer/wego/setting"
+)
+
+type TagRouter struct {
+	PostListRouter
+}
+
+// load the tag by slug, synonyms redirect to the tags they stand for
+func (this *TagRouter) loadTag(suffix string) *models.Tag {
+	tag, err := models.GetTagBySlug(this.Params().Get(":slug"))
+	if err != nil {
+		this.NotFound()
+		return nil
+	}
+	if synonym := tag.Synonym(); synonym != nil {
+		this.Redirect(synonym.Link()+suffix, 301)
+		return nil
+	}
+	this.Data["Tag"] = tag
+	return tag
+}
+
+// All tags
+type Tags struct {
+	PostListRouter
+}
+
+func (this *Tags) Get() error {
+	cnt, err := models.CountTags()
+	if err != nil {
+		return err
+	}
+
+	limit := 60
+	pager := this.SetPaginator(limit, cnt)
+	tags, err := models.FindTags(limit, pager.Offset())
+	if err != nil {
+		return err
+	}
+	this.Data["Tags"] = tags
+	return this.Render("post/tags.html", this.Data)
+}
+
+// Posts of tag
+type Tag struct {
+	TagRouter
+}
+
+func (this *Tag) Get() error {
+	tag := this.loadTag("")
+	if tag == nil {
+		return nil
+	}
+
+	cnt, err := models.CountPostsByTag(&models.Post{}, tag.Id, this.ContentLangs())
+	if err != nil {
+		return err
+	}
+
+	pager := this.SetPaginator(setting.PostCountPerPage, cnt)
+	posts, err := models.RecentPostsByTag("hot", &models.Post{}, tag.Id, this.ContentLangs(), setting.PostCountPerPage, pager.Offset())
+	if err != nil {
+		return err
+	}
+
+	this.Data["Posts"] = posts
+	this.setReadStates(posts)
+	this.Data["StickyLevel"] = setting.STICKY_GLOBAL
+	this.setSidebarBuilletinInfo()
+	return this.Render("post/tag.html", this.Data)
+}
+
+// RSS feed of the recent posts of tag
+type TagFeed struct {
+	TagRouter
+}
+
+func (this *TagFeed) Get() {
+	tag := this.loadTag("/feed")
+	if tag == nil {
+		return
+	}
+
+	posts, err := models.RecentPostsByTag("recent", &models.Post{}, tag.Id, this.ContentLangs(), setting.PostCountPerPage, 0)
+	if err != nil {
+		log.Error("TagFeed error:", err)
+		this.NotFound()
+		return
+	}
+
+	title := "#" + tag.Name + " - " + this.Tr("app_name")
+	data, err := post.PostsFeed(title, tag.Link(), tag.Intro, posts)
+	if err != nil {
+		log.Error("TagFeed error:", err)
+		this.NotFound()
+		return
+	}
+	this.Header().Set("Content-Type", "application/rss+xml; charset=utf-8")
+	this.Write(data)
+}
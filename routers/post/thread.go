@@ -0,0 +1,154 @@
+package post
+
+import (
+	"github.com/lunny/log"
+	"github.com/missdeer/wego/models"
+	"github.com/missdeer/wego/modules/post"
+	"github.com/missdeer/wego/modules/utils"
+)
+
+// Move, split and merge post by moderators
+type ModeratePost struct {
+	PostRouter
+	perm models.Permission
+}
+
+func (this *ModeratePost) loadModeratePost(post *models.Post) bool {
+	if this.CheckActiveRedirect() {
+		return true
+	}
+
+	if this.loadPost(post, nil) {
+		return true
+	}
+
+	this.perm = this.loadPermission(post)
+	if !this.perm.CanMovePost() || post.RedirectId > 0 {
+		this.NotFound()
+		return true
+	}
+	return false
+}
+
+// Get the topics which current user can move posts to
+func (this *ModeratePost) movableTopics() []models.Topic {
+	var topics, movable []models.Topic
+	models.FindTopics(&topics)
+	for _, topic := range topics {
+		if models.GetPermission(&this.User, topic.CategoryId, topic.Id).CanMovePost() {
+			movable = append(movable, topic)
+		}
+	}
+	return movable
+}
+
+func (this *ModeratePost) render(postMd *models.Post, topics []models.Topic) {
+	if _, ok := this.Data["PostMoveFormSets"]; !ok {
+		form := post.PostMoveForm{Topic: postMd.TopicId, LeaveNote: true, Topics: topics}
+		this.SetFormSets(&form)
+	}
+	if _, ok := this.Data["PostSplitFormSets"]; !ok {
+		form := post.PostSplitForm{Topic: postMd.TopicId, Topics: topics}
+		this.SetFormSets(&form)
+	}
+	if _, ok := this.Data["PostMergeFormSets"]; !ok {
+		form := post.PostMergeForm{}
+		this.SetFormSets(&form)
+	}
+
+	//all comments can be split out
+	var comments []*models.Comment
+	if err := models.GetCommentsByPostId(&comments, postMd.Id); err != nil {
+		log.Error("GetCommentsByPostId error:", err)
+	}
+	this.Data["Comments"] = comments
+	this.Data["CommentsNum"] = len(comments)
+	this.Render("post/moderate.html", this.Data)
+}
+
+func (this *ModeratePost) Get() {
+	var postMd models.Post
+	if this.loadModeratePost(&postMd) {
+		return
+	}
+
+	this.render(&postMd, this.movableTopics())
+}
+
+func (this *ModeratePost) Post() {
+	var postMd models.Post
+	if this.loadModeratePost(&postMd) {
+		return
+	}
+
+	topics := this.movableTopics()
+	switch this.GetString("action") {
+	case "move":
+		form := post.PostMoveForm{Topics: topics}
+		if !this.ValidFormSets(&form) {
+			break
+		}
+		topic, err := models.GetTopicById(form.Topic)
+		if err == nil {
+			err = models.MovePost(&postMd, topic, form.LeaveNote)
+		}
+		if err == nil {
+			this.FlashRedirect(postMd.Path(), 302, "PostMoved")
+			return
+		}
+		log.Error("MovePost:", err)
+		this.Data["Error"] = err
+
+	case "split":
+		form := post.PostSplitForm{Topics: topics}
+		if !this.ValidFormSets(&form) {
+			break
+		}
+
+		var commentIds []int64
+		for _, value := range this.Req().Form["comments"] {
+			if id, err := utils.StrTo(value).Int64(); err == nil {
+				commentIds = append(commentIds, id)
+			}
+		}
+		if len(commentIds) == 0 {
+			this.SetFormError(&form, "Title", "post.moderate_split_need_comments")
+			break
+		}
+
+		topic, err := models.GetTopicById(form.Topic)
+		var newPost *models.Post
+		if err == nil {
+			newPost, err = models.SplitPost(&postMd, commentIds, form.Title, topic)
+		}
+		if err == nil {
+			this.FlashRedirect(newPost.Path(), 302, "PostSplit")
+			return
+		}
+		log.Error("SplitPost:", err)
+		this.Data["Error"] = err
+
+	case "merge":
+		form := post.PostMergeForm{}
+		if !this.ValidFormSets(&form) {
+			break
+		}
+
+		target, err := models.GetPostById(form.Target)
+		if err != nil || target.RedirectId > 0 || target.Id == postMd.Id ||
+			!models.GetPostPermission(&this.User, target).CanMovePost() {
+			this.SetFormError(&form, "Target", "post.moderate_merge_wrong_target")
+			break
+		}
+
+		if err := models.MergePost(&postMd, target); err == nil {
+			this.FlashRedirect(target.Path(), 302, "PostMerged")
+			return
+		} else {
+			log.Error("MergePost:", err)
+			this.Data["Error"] = err
+		}
+	}
+
+	this.render(&postMd, topics)
+}
@@ -23,7 +23,9 @@ import (
 	"net/url"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/Unknwon/goconfig"
@@ -59,6 +61,8 @@ var (
 	DateTimeShortFormat string
 	TimeZone            string
 	RealtimeRenderMD    bool
+	GoDocURL            string
+	GoFmtBadge          bool
 	ImageSizeSmall      int
 	ImageSizeMiddle     int
 	ImageLinkAlphabets  []byte
@@ -108,6 +112,23 @@ var (
 
 var (
 	PostCountPerPage int
+	// minutes authors can edit their comments, 0 means no limit
+	CommentEditMinutes  int
+	PostMaxTags         int
+	PollMaxOptions      int
+	CommentCountPerPage int
+)
+
+var (
+	// reputation of active users who can edit the open wiki pages
+	WikiEditReputation int
+	// reputation of active users who can edit the semi-protected wiki pages
+	WikiProtectedReputation int
+)
+
+var (
+	// reverse proxies which X-Forwarded-For header can be trusted
+	TrustedProxies []string
 )
 
 var (
@@ -126,6 +147,20 @@ const (
 	BULLETIN_MOBILE_APP
 )
 
+// positions of menus
+const (
+	MENU_NAVBAR = iota
+	MENU_FOOTER
+)
+
+// sticky level of post, a post sorts first in the listings at or under its level
+const (
+	STICKY_NONE = iota
+	STICKY_TOPIC
+	STICKY_CATEGORY
+	STICKY_GLOBAL
+)
+
 const (
 	AvatarImageMaxLength   = 500 * 1024
 	AvatarTypeGravatar     = 1
@@ -140,6 +175,17 @@ const (
 	NOTICE_READ   = 2
 )
 
+const (
+	VOTE_TARGET_POST    = 1
+	VOTE_TARGET_COMMENT = 2
+)
+
+const (
+	DRAFT_TYPE_POST    = 1
+	DRAFT_TYPE_EDIT    = 2
+	DRAFT_TYPE_COMMENT = 3
+)
+
 var (
 	// Social Auth
 	GithubAuth *apps.Github
@@ -160,7 +206,14 @@ var (
 )
 
 var (
+	// index of the lang used when the visitor has none, the other langs
+	// fall back to it for the missing keys
 	DefaultLang = LangZhCN
+
+	// keys of the default lang which are not translated, by lang, they are
+	// found again when the locale files are reloaded
+	untranslatedKeys     map[string][]string
+	untranslatedKeysLock sync.RWMutex
 )
 
 var (
@@ -339,6 +392,8 @@ func reloadConfig() {
 	CookieUserName = Cfg.MustValue("app", "cookie_user_name", "wetalk_powerful")
 
 	RealtimeRenderMD = Cfg.MustBool("app", "realtime_render_markdown")
+	GoDocURL = Cfg.MustValue("app", "go_doc_url", "https://pkg.go.dev/{path}")
+	GoFmtBadge = Cfg.MustBool("app", "go_fmt_badge")
 
 	ImageSizeSmall = Cfg.MustInt("image", "image_size_small")
 	ImageSizeMiddle = Cfg.MustInt("image", "image_size_middle")
@@ -392,25 +447,199 @@ func reloadConfig() {
 
 	//post
 	PostCountPerPage = Cfg.MustInt("post", "post_count_per_page", 20)
+	CommentEditMinutes = Cfg.MustInt("post", "comment_edit_minutes", 30)
+	PostMaxTags = Cfg.MustInt("post", "post_max_tags", 5)
+	PollMaxOptions = Cfg.MustInt("post", "poll_max_options", 10)
+	CommentCountPerPage = Cfg.MustInt("post", "comment_count_per_page", 50)
+
+	//wiki
+	WikiEditReputation = Cfg.MustInt("wiki", "wiki_edit_reputation", 100)
+	WikiProtectedReputation = Cfg.MustInt("wiki", "wiki_protected_reputation", 1000)
+
+	//security
+	TrustedProxies = strings.Split(Cfg.MustValue("security", "trusted_proxies", "127.0.0.1|::1"), "|")
+}
+
+// localeFiles returns the locale_LANG.ini files of lang, the ones in conf
+// override the ones in conf/global.
+func localeFiles(lang string) []string {
+	var files []string
+	for _, dir := range []string{"conf/global/", "conf/"} {
+		file := dir + "locale_" + lang + ".ini"
+		if fh, err := os.Open(file); err == nil {
+			fh.Close()
+			files = append(files, file)
+		}
+	}
+	return files
+}
+
+// discoverLangs returns the langs of locale_LANG.ini files. Users and posts
+// save the index of their lang, so the langs listed in [i18n] langs keep
+// their order, the others are appended in name order with a warning.
+func discoverLangs() ([]string, error) {
+	found := make(map[string]bool)
+	for _, pattern := range []string{"conf/global/locale_*.ini", "conf/locale_*.ini"} {
+		files, _ := filepath.Glob(pattern)
+		for _, file := range files {
+			name := strings.TrimSuffix(strings.TrimPrefix(filepath.Base(file), "locale_"), ".ini")
+			if len(name) > 0 {
+				found[name] = true
+			}
+		}
+	}
+
+	langs := make([]string, 0, len(found))
+	listed := make(map[string]bool)
+	for _, lang := range strings.Split(Cfg.MustValue("i18n", "langs", "en-US|zh-CN"), "|") {
+		lang = strings.TrimSpace(lang)
+		if len(lang) == 0 {
+			continue
+		}
+		if listed[lang] {
+			return nil, fmt.Errorf("lang %s is listed twice in [i18n] langs", lang)
+		}
+		if !found[lang] {
+			return nil, fmt.Errorf("lang %s in [i18n] langs has no locale_%s.ini file", lang, lang)
+		}
+		listed[lang] = true
+		langs = append(langs, lang)
+	}
+
+	var unlisted []string
+	for lang := range found {
+		if !listed[lang] {
+			unlisted = append(unlisted, lang)
+		}
+	}
+	if len(unlisted) > 0 {
+		sort.Strings(unlisted)
+		log.Warnf("Locales %s are not listed in [i18n] langs, list them to keep their indexes", strings.Join(unlisted, ", "))
+	}
+	return append(langs, unlisted...), nil
 }
 
 func settingLocales() {
 	// load locales with locale_LANG.ini files
-	langs := "en-US|zh-CN"
-	for _, lang := range strings.Split(langs, "|") {
-		lang = strings.TrimSpace(lang)
-		files := []interface{}{"conf/" + "locale_" + lang + ".ini"}
-		if fh, err := os.Open(files[0].(string)); err == nil {
-			fh.Close()
-		} else {
-			files = nil
+	langs, err := discoverLangs()
+	if err != nil {
+		log.Error("Fail to find locales: " + err.Error())
+		os.Exit(2)
+	}
+	if len(langs) == 0 {
+		log.Error("Fail to find any locale_LANG.ini file")
+		os.Exit(2)
+	}
+
+	DefaultLang = 0
+	defaultLang := Cfg.MustValue("i18n", "default_lang", "zh-CN")
+	for i, lang := range langs {
+		if lang == defaultLang {
+			DefaultLang = i
+		}
+	}
+	defaultLang = langs[DefaultLang]
+	defaultFiles := localeFiles(defaultLang)
+
+	for _, lang := range langs {
+		files := localeFiles(lang)
+		if lang != defaultLang {
+			// the missing keys fall back to the default lang
+			files = append(append([]string{}, defaultFiles...), files...)
+		}
+
+		others := make([]interface{}, 0, len(files)-1)
+		for _, file := range files[1:] {
+			others = append(others, file)
 		}
-		if err := i18n.SetMessage(lang, "conf/global/"+"locale_"+lang+".ini", files...); err != nil {
+		if err := i18n.SetMessage(lang, files[0], others...); err != nil {
 			log.Error("Fail to set message file: " + err.Error())
 			os.Exit(2)
 		}
 	}
 	Langs = i18n.ListLangs()
+	setUntranslatedKeys(findUntranslatedKeys(Langs, DefaultLang))
+}
+
+// reloadLocales finds the untranslated keys of the loaded langs again after
+// the locale files are reloaded. The messages of i18n are read without lock,
+// so the langs are only loaded at start, new ones need a restart.
+func reloadLocales() {
+	langs, err := discoverLangs()
+	if err != nil {
+		log.Error("Locales Reload: ", err)
+	} else {
+		for i, lang := range Langs {
+			if i >= len(langs) || langs[i] != lang {
+				log.Error("Locales Reload: loaded langs are removed or reordered, restart to apply [i18n] langs")
+				break
+			}
+		}
+		if len(langs) > len(Langs) {
+			log.Warnf("Locales Reload: restart to load the new langs %s", strings.Join(langs[len(Langs):], ", "))
+		}
+	}
+	setUntranslatedKeys(findUntranslatedKeys(Langs, DefaultLang))
+}
+
+// findUntranslatedKeys lists the missing keys of the langs by lang, they
+// fall back to the default lang.
+func findUntranslatedKeys(langs []string, defaultLang int) map[string][]string {
+	keys := make(map[string][]string)
+	defaultFiles := localeFiles(langs[defaultLang])
+	for i, lang := range langs {
+		if i == defaultLang {
+			continue
+		}
+		keys[lang] = missingLocaleKeys(defaultFiles, localeFiles(lang))
+		if n := len(keys[lang]); n > 0 {
+			log.Warnf("Locale %s has %d untranslated keys", lang, n)
+		}
+	}
+	return keys
+}
+
+func setUntranslatedKeys(keys map[string][]string) {
+	untranslatedKeysLock.Lock()
+	untranslatedKeys = keys
+	untranslatedKeysLock.Unlock()
+}
+
+// UntranslatedKeys returns the keys of the default lang which are not
+// translated, by lang. The map is replaced on reload, not changed.
+func UntranslatedKeys() map[string][]string {
+	untranslatedKeysLock.RLock()
+	defer untranslatedKeysLock.RUnlock()
+	return untranslatedKeys
+}
+
+// missingLocaleKeys lists the keys of the base locale files which the
+// translated files do not have, like section.key.
+func missingLocaleKeys(base, translated []string) []string {
+	keys := make([]string, 0)
+	baseCfg, err := goconfig.LoadConfigFile(base[0], base[1:]...)
+	if err != nil {
+		log.Error("Fail to load locale file: " + err.Error())
+		return keys
+	}
+	cfg, err := goconfig.LoadConfigFile(translated[0], translated[1:]...)
+	if err != nil {
+		log.Error("Fail to load locale file: " + err.Error())
+		return keys
+	}
+	for _, section := range baseCfg.GetSectionList() {
+		for _, key := range baseCfg.GetKeyList(section) {
+			if _, err := cfg.GetValue(section, key); err == nil {
+				continue
+			}
+			if section == goconfig.DEFAULT_SECTION {
+				keys = append(keys, key)
+			} else {
+				keys = append(keys, section+"."+key)
+			}
+		}
+	}
+	return keys
 }
 
 var Funcs = make(template.FuncMap)
@@ -460,6 +689,7 @@ func configWatcher() {
 					}
 
 					reloadConfig()
+					reloadLocales()
 					log.Info("Config Reloaded")
 
 				case ".json":
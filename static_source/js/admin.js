@@ -5,7 +5,7 @@
 			var $e = $(e);
 			var model = $e.data('model');
 			$e.select2({
-				minimumInputLength: 3,
+				minimumInputLength: $e.data('min-input') || 3,
 				ajax: {
 					url: '/admin/model/select',
 					type: 'POST',
@@ -46,6 +46,21 @@
 				}
 			});
 		});
+
+		$('[rel=admin-post-sticky]').on('change', function(){
+			var $e = $(this);
+			$.post('/api/post', {'action': 'set-sticky', 'post': $e.data('post'), 'sticky': $e.val()}, function(d){
+				if(!d.success){
+					window.location.reload();
+				}
+			});
+		});
+
+		$('[rel=admin-post-lock]').on('click', function(){
+			$.post('/api/post', {'action': 'toggle-lock', 'post': $(this).data('post')}, function(){
+				window.location.reload();
+			});
+		});
 	});
 
 })(jQuery);
\ No newline at end of file
@@ -331,8 +331,32 @@
                 $.jStorage.set(saveKey, $textarea.val());
             }, 500);
 
+            // autosave draft to server, so it's available on other machines
+            var draftUrl = $editor.data('draft-url');
+            var intervalDraft;
+            if(draftUrl){
+                var $form = $textarea.parents('form:first');
+                var draftCache = $textarea.val();
+                intervalDraft = setInterval(function(){
+                    var content = $textarea.val();
+                    var title = $form.find('[name=Title]').val() || '';
+                    if(content + title == draftCache) return;
+                    draftCache = content + title;
+                    $.post(draftUrl, {
+                        'action': 'save',
+                        'type': $editor.data('draft-type'),
+                        'post': $editor.data('draft-post') || '',
+                        'base': $editor.data('draft-base') || '',
+                        'topic': $form.find('[name=Topic]').val() || '',
+                        'title': title,
+                        'content': content
+                    });
+                }, 10000);
+            }
+
             $textarea.parents('form:first').on('submit', function(){
                 clearInterval(intervalSave);
+                clearInterval(intervalDraft);
             });
 
             $textarea.autosize();
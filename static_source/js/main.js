@@ -176,35 +176,57 @@
 				}
 			});
 
-			$e.children('p, ol, ul, blockquote').each(function(i,e){
-				$(e).replaceWith(function(){
-					var links = {};
-					var elms = $($(e).outerHTML());
-					elms.find('a').each(function(i,e){
-						links[i] = $(e).outerHTML();
-						$(e).replaceWith('start-ph-a-'+i+'-end');
-					});
-					var html = elms.outerHTML().replace(/\B([@#])([\d\w-_]+)/g, function(_,p1,p2){
-						var link, attrs;
-						if(p1 == '@'){
-							link = '/user/'+p2;
-							attrs = 'target="_blank"';
-						} else {
-							link = '#reply'+p2;
-							attrs = 'rel="floor-link"';
+			// anchor links of headings
+			$e.find('h1[id], h2[id], h3[id], h4[id], h5[id], h6[id]').each(function(_, h){
+				var $h = $(h);
+				$h.prepend('<a class="heading-anchor" href="#'+$h.attr('id')+'">#</a>');
+			});
+
+			// titles of linked posts, posts which can't be seen have none
+			var $links = $e.find('a.post-link'), ids = {};
+			$links.each(function(_, a){
+				var m = /\/post\/(\d+)/.exec($(a).attr('href'));
+				if(m){
+					ids[m[1]] = true;
+				}
+			});
+			ids = $.map(ids, function(_, id){ return id; });
+			if(ids.length > 0){
+				$.post('/api/md', {action: 'post-titles', ids: ids.join(',')}, function(data){
+					if(!data.success){
+						return;
+					}
+					$links.each(function(_, a){
+						var m = /\/post\/(\d+)/.exec($(a).attr('href'));
+						if(m && data.titles[m[1]]){
+							$(a).attr('title', data.titles[m[1]]);
 						}
-						return '<a href="'+link+'" '+attrs+'>'+p1+p2+'</a>';
 					});
-					html = html.replace(/start-ph-a-(\d)-end/g, function(_,i){
-						return links[i]
-					});
-					return html
 				});
-			});
+			}
 
-			var $pre = $e.find('pre > code').parent();
-			$pre.addClass("prettyprint");
-			prettyPrint();
+			// links to wiki pages which are not published yet are shown as missing
+			var $wikiLinks = $e.find('a.wiki-link'), uris = {};
+			$wikiLinks.each(function(_, a){
+				var m = /(\/page\/[^\/?#]+)$/.exec($(a).attr('href'));
+				if(m){
+					uris[m[1]] = true;
+				}
+			});
+			uris = $.map(uris, function(_, uri){ return uri; });
+			if(uris.length > 0){
+				$.post('/api/md', {action: 'wiki-pages', uris: uris.join(',')}, function(data){
+					if(!data.success){
+						return;
+					}
+					$wikiLinks.each(function(_, a){
+						var m = /(\/page\/[^\/?#]+)$/.exec($(a).attr('href'));
+						if(m && !data.pages[m[1]]){
+							$(a).addClass('wiki-missing');
+						}
+					});
+				});
+			}
 		};
 
 	})();
@@ -229,30 +251,124 @@
 
 		$('[rel=select2]').select2();
 
+		// free-form tags of post with autocomplete
+		$('[rel=post-tags]').each(function(_, e){
+			var $e = $(e);
+			$e.select2({
+				multiple: true,
+				tokenSeparators: [',', ' '],
+				createSearchChoice: function(term){
+					return {'id': term, 'text': term};
+				},
+				ajax: {
+					url: '/api/tag',
+					type: 'POST',
+					data: function(query){
+						return {'action': 'search', 'q': query};
+					},
+					results: function(d){
+						var results = [];
+						if(d.success && d.data){
+							$.each(d.data, function(i, v){
+								results.push({'id': v, 'text': v});
+							});
+						}
+						return {'results': results};
+					}
+				},
+				initSelection: function(elm, cbk){
+					var data = [];
+					$.each($e.val().split(','), function(i, v){
+						if(v){
+							data.push({'id': v, 'text': v});
+						}
+					});
+					cbk(data);
+				}
+			});
+		});
+
 		$('.markdown').mdFilter();
 	});
 
 
 	$.extend($, {
 		postPage: function(){
+			var $reply = $('#post-reply'),
+			$parent = $reply.find('[name=Parent]'),
+			$replyParent = $reply.find('.reply-parent');
+
+			// remember the replied comment of new comment
+			function setParent($e){
+				if(!$e || !$e.length){
+					$parent.val(0);
+					$replyParent.hide();
+					return;
+				}
+				$parent.val($e.data('id'));
+				$replyParent.find('[rel=reply-parent-link]').attr('href', '#reply'+$e.data('floor')).text('#'+$e.data('floor')+' @'+$e.data('user'));
+				$replyParent.show();
+			}
+
+			$(document).on('click', '[rel=reply-parent-cancel]', function(){
+				setParent(null);
+			});
+
 			// comment reply
 			$(document).on('click', '[rel=comment-reply]', function(){
 				var $e = $(this).parents('.comment:first'),
 				api = $('#md-editor').data('editor'),
 				user = $e.data('user'),
-				floor = $e.data('floor'),
 				sel = api.getSel(),
-				v = '#'+floor+' @'+user+' ';
-				$('#post-reply').ScrollTo();
+				v = '@'+user+' ';
+				setParent($e);
+				$reply.ScrollTo();
+				api.insertText(v, sel.start + v.length);
+			});
+
+			// quote the comment, or the selected text of it, as markdown blockquote
+			$(document).on('click', '[rel=comment-quote]', function(){
+				var $e = $(this).parents('.comment:first'),
+				api = $('#md-editor').data('editor'),
+				user = $e.data('user'),
+				floor = $e.data('floor'),
+				link = $reply.attr('action').replace(/#.*$/, '') + '#reply' + floor,
+				text = $.trim($e.find('.content > .markdown').text()),
+				selected = $.trim(window.getSelection ? window.getSelection().toString() : '');
+				if(selected && $.trim($e.text()).indexOf(selected) != -1){
+					text = selected;
+				}
+				var v = '> @'+user+' [#'+floor+']('+link+'):\n';
+				$.each(text.split('\n'), function(_, line){
+					v += '> '+$.trim(line)+'\n';
+				});
+				v += '\n';
+				var sel = api.getSel();
+				setParent($e);
+				$reply.ScrollTo();
 				api.insertText(v, sel.start + v.length);
 			});
 
+			if($parent.val() > 0){
+				setParent($('.comment[data-id='+$parent.val()+']'));
+			}
+
 			var $comments = $('.post-comments');
 
 			$(window).on('hashchange', function(){
-				if(/#reply\d+/.test(window.location.hash)){
+				var m = /^#reply(\d+)$/.exec(window.location.hash);
+				if(m){
 					$comments.find('.comment').removeClass('highlight');
 					var $e = $(window.location.hash);
+					if(!$e.length && $comments.data('per-page')){
+						// the floor is listed on another page
+						var page = Math.floor((m[1] - 1) / $comments.data('per-page')) + 1,
+						current = /[?&]p=(\d+)/.exec(window.location.search);
+						if(page != (current ? current[1] : 1) || /[?&]sort=/.test(window.location.search)){
+							window.location.href = $comments.data('link') + '?floor=' + m[1];
+							return;
+						}
+					}
 					$e.addClass('highlight');
 				}
 			});
@@ -17,6 +17,8 @@
 package main
 
 import (
+	"flag"
+	"os"
 	"time"
 
 	_ "github.com/go-sql-driver/mysql"
@@ -25,6 +27,8 @@ import (
 	_ "github.com/mattn/go-sqlite3"
 	"github.com/missdeer/wego/middlewares"
 	"github.com/missdeer/wego/models"
+	"github.com/missdeer/wego/modules/ipblock"
+	"github.com/missdeer/wego/modules/post"
 	"github.com/missdeer/wego/routers"
 	"github.com/missdeer/wego/routers/auth"
 	"github.com/missdeer/wego/setting"
@@ -35,6 +39,11 @@ import (
 	"github.com/tango-contrib/xsrf"
 )
 
+var (
+	repairFloors     = flag.Bool("repair-floors", false, "renumber the comment floors of every post and fix the notifications, then exit")
+	rerenderMarkdown = flag.Bool("rerender-markdown", false, "render the markdown of every post, comment, page and notification again, then exit")
+)
+
 func initTango(isprod bool) *tango.Tango {
 	middlewares.Init()
 
@@ -63,6 +72,7 @@ func initTango(isprod bool) *tango.Tango {
 			RootPath: "./static_source",
 			Prefix:   "static_source",
 		}),
+		middlewares.IpBlock(),
 		sess,
 		middlewares.Renders,
 		setting.Captcha,
@@ -78,12 +88,40 @@ func initTango(isprod bool) *tango.Tango {
 }
 
 func main() {
+	flag.Parse()
+
 	// init configs
 	setting.LoadConfig()
 
 	// init models
 	models.Init(setting.IsProMode)
 
+	// one-off repair of the floors numbered before they were per post
+	if *repairFloors {
+		if err := models.RepairFloors(); err != nil {
+			setting.Log.Error("repair floors:", err)
+			os.Exit(1)
+		}
+		setting.Log.Info("comment floors repaired")
+		return
+	}
+
+	// one-off refresh of the rendered caches after the markdown renderer changed
+	if *rerenderMarkdown {
+		if err := models.RerenderMarkdown(); err != nil {
+			setting.Log.Error("rerender markdown:", err)
+			os.Exit(1)
+		}
+		setting.Log.Info("markdown rerendered")
+		return
+	}
+
+	// init ip block list
+	ipblock.Init()
+
+	// init sticky posts expiring
+	post.Init()
+
 	// init social
 	social.SetORM(models.ORM())
 	setting.SocialAuth = social.NewSocial("/login/", auth.SocialAuther)